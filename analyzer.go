@@ -0,0 +1,629 @@
+package ruleenginecore
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// 'DiagnosticKind' defines kind of a problem reported by 'Analyze'
+type DiagnosticKind string
+
+const (
+	// rule condition can never be satisfied, hence rule never matches, reported with SeverityError
+	UnsatisfiableRule DiagnosticKind = "unsatisfiableRule"
+
+	// rule condition is always satisfied, hence rule matches every input, reported with SeverityWarning
+	TautologicalRule DiagnosticKind = "tautologicalRule"
+
+	// sub-condition of 'and' is implied by other sub-conditions, or sub-condition of 'or' implies other
+	// sub-conditions, removing the sub-condition does not change the outcome, reported with SeverityInfo
+	RedundantCondition DiagnosticKind = "redundantCondition"
+)
+
+// 'Severity' defines severity level of a Diagnostic, levels are ordered hence diagnostics can be filtered by minimum
+// severity (ex. diagnostic.Severity >= SeverityWarning)
+type Severity int
+
+const (
+	// configuration can be simplified, such as a redundant condition
+	SeverityInfo Severity = iota + 1
+
+	// configuration is valid but likely not as intended, such as a rule which always matches
+	SeverityWarning
+
+	// configuration is valid but certainly not as intended, such as a rule which never matches
+	SeverityError
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// 'Diagnostic' is a problem found by analyzing RuleEngineConfig
+type Diagnostic struct {
+	Kind     DiagnosticKind `json:"kind"`
+	Severity Severity       `json:"severity"`
+	RuleName string         `json:"ruleName,omitempty"`
+
+	// JSON path of the condition within RuleEngineConfig (ex. `rules["ruleX"].condition.subConditions[1]`)
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// 'Analyze' statically analyzes rules of the configuration and reports rules which can never match or always match,
+// and sub-conditions which are redundant. Diagnostics are ordered by rule name and condition depth first.
+//
+// Conditions comparing a field with a constant ('>', '>=', '<', '<=', '==', '!=', 'in', 'notIn') and presence
+// conditions ('isPresent', 'isMissing') are analyzed as range of values per field. Any other condition type is
+// considered as an independent condition, which may or may not be satisfied. Rules having too many combinations of
+// ranges are not analyzed.
+//
+// Configuration is validated same as 'New', error is returned for invalid configuration.
+func Analyze(engineConfig *RuleEngineConfig, opts ...EngineOption) ([]*Diagnostic, error) {
+	engineOp := newEngineOption(opts...)
+	// validation parses values into the configuration, caller's configuration is kept as is
+	engineConfig = engineConfig.clone()
+	if err := engineOp.registry.validator.validate(engineConfig, engineOp.dateTimeLayout); err != nil {
+		return nil, err
+	}
+
+	a := &analyzer{config: engineConfig}
+	ret := []*Diagnostic{}
+	for _, ruleName := range sortedKeys(engineConfig.Rules) {
+		ret = append(ret, a.analyzeRule(ruleName, engineConfig.Rules[ruleName].RootCondition)...)
+	}
+	return ret, nil
+}
+
+// maximum number of boxes maintained while analyzing a condition, analysis is given up beyond it
+const maxAnalysisBoxes = 1024
+
+type analyzer struct {
+	config *RuleEngineConfig
+}
+
+func (a *analyzer) analyzeRule(ruleName string, rootCondition *Condition) []*Diagnostic {
+	path := configPath("rules", ruleName, "condition")
+
+	satisfying, ok := a.boxes(rootCondition, false)
+	if !ok {
+		return nil
+	}
+	if len(satisfying) == 0 {
+		return []*Diagnostic{{Kind: UnsatisfiableRule, Severity: SeverityError, RuleName: ruleName, Path: path,
+			Message: "rule never matches, condition can never be satisfied"}}
+	}
+
+	unsatisfying, ok := a.boxes(rootCondition, true)
+	if !ok {
+		return nil
+	}
+	if len(unsatisfying) == 0 {
+		return []*Diagnostic{{Kind: TautologicalRule, Severity: SeverityWarning, RuleName: ruleName, Path: path,
+			Message: "rule matches every input, condition is always satisfied"}}
+	}
+
+	return a.redundantConditions(ruleName, rootCondition, path)
+}
+
+// reports redundant sub-conditions of 'and' and 'or' conditions of the tree, sub-condition already reported is not
+// considered while checking its siblings, hence only one of duplicate sub-conditions is reported
+func (a *analyzer) redundantConditions(ruleName string, condition *Condition, path string) []*Diagnostic {
+	ret := []*Diagnostic{}
+	isAnd := condition.Type == AndCondition
+	if isAnd || condition.Type == OrCondition {
+		redundant := map[int]bool{}
+		for i, subCondition := range condition.SubConditions {
+			others := &Condition{Type: condition.Type}
+			for j, sibling := range condition.SubConditions {
+				if j != i && !redundant[j] {
+					others.SubConditions = append(others.SubConditions, sibling)
+				}
+			}
+
+			// 'and' sub-condition is redundant if others imply it, 'or' sub-condition is redundant if it implies others
+			var boxes []box
+			var ok bool
+			if isAnd {
+				boxes, ok = a.intersection(others, false, subCondition, true)
+			} else {
+				boxes, ok = a.intersection(subCondition, false, others, true)
+			}
+			if !ok || len(boxes) != 0 {
+				continue
+			}
+
+			redundant[i] = true
+			message := "condition is implied by other sub-conditions of 'and', hence it is redundant"
+			if !isAnd {
+				message = "condition implies other sub-conditions of 'or', hence it is redundant"
+			}
+			ret = append(ret, &Diagnostic{Kind: RedundantCondition, Severity: SeverityInfo, RuleName: ruleName,
+				Path: fmt.Sprintf("%v.subConditions[%v]", path, i), Message: message})
+		}
+	}
+
+	for i, subCondition := range condition.SubConditions {
+		ret = append(ret, a.redundantConditions(ruleName, subCondition, fmt.Sprintf("%v.subConditions[%v]", path, i))...)
+	}
+	return ret
+}
+
+// returns boxes satisfying both conditions, either condition is negated if respective 'negated' is true
+func (a *analyzer) intersection(first *Condition, firstNegated bool, second *Condition, secondNegated bool) ([]box, bool) {
+	firstBoxes, ok := a.boxes(first, firstNegated)
+	if !ok {
+		return nil, false
+	}
+	secondBoxes, ok := a.boxes(second, secondNegated)
+	if !ok {
+		return nil, false
+	}
+	return intersectBoxes(firstBoxes, secondBoxes)
+}
+
+// returns boxes, union of which is set of inputs satisfying the condition, or not satisfying the condition if
+// 'negated' is true. Empty list of boxes represents unsatisfiable condition.
+func (a *analyzer) boxes(condition *Condition, negated bool) ([]box, bool) {
+	switch condition.Type {
+	case NegationCondition:
+		return a.boxes(condition.SubConditions[0], !negated)
+	case AndCondition, OrCondition:
+		// negated 'and' is 'or' of negated sub-conditions and vice versa
+		if (condition.Type == AndCondition) != negated {
+			ret := []box{{}}
+			for _, subCondition := range condition.SubConditions {
+				boxes, ok := a.boxes(subCondition, negated)
+				if !ok {
+					return nil, false
+				}
+				if ret, ok = intersectBoxes(ret, boxes); !ok {
+					return nil, false
+				}
+			}
+			return ret, true
+		}
+
+		ret := []box{}
+		for _, subCondition := range condition.SubConditions {
+			boxes, ok := a.boxes(subCondition, negated)
+			if !ok {
+				return nil, false
+			}
+			ret = append(ret, boxes...)
+		}
+		return ret, len(ret) <= maxAnalysisBoxes
+	}
+
+	field, domain := a.conditionDomain(condition.Type)
+	if negated {
+		domain = domain.complement()
+	}
+	if domain.isEmpty() {
+		return []box{}, true
+	}
+	return []box{{field: domain}}, true
+}
+
+// returns field and its values satisfying the condition type, condition type which can not be analyzed is considered
+// as a boolean pseudo field named after the condition type
+func (a *analyzer) conditionDomain(conditionTypeName string) (string, fieldDomain) {
+	ct := a.config.ConditionTypes[conditionTypeName]
+
+	if (ct.Operator == IsPresentOperator || ct.Operator == IsMissingOperator) && len(ct.Operands) == 1 {
+		field := ct.Operands[firstOperand].Val
+		domain := a.universe(ct.Operands[firstOperand].ValueType, a.isNullable(field))
+		if domain != nil {
+			domain = domain.withoutMissing(true)
+			if ct.Operator == IsMissingOperator {
+				domain = domain.complement()
+			}
+			return field, domain
+		}
+	}
+
+	if field, domain, ok := a.comparisonDomain(ct); ok {
+		return field, domain
+	}
+
+	// condition is satisfied for the pseudo field value 'true'
+	domain := &discreteSet{values: map[any]bool{true: true}, universe: []any{true, false}}
+	return reservedFieldPrefix + "condition:" + conditionTypeName, domain
+}
+
+// returns field and its values satisfying field and constant comparison
+func (a *analyzer) comparisonDomain(ct *ConditionType) (string, fieldDomain, bool) {
+	if len(ct.Operands) != 2 {
+		return "", nil, false
+	}
+	field, constant := ct.Operands[firstOperand], ct.Operands[secondOperand]
+	operator := ct.Operator
+	if !field.isField() {
+		field, constant = constant, field
+		operator = swappedOperators[operator]
+	}
+	if !field.isField() || constant.Type != Constant {
+		return "", nil, false
+	}
+
+	universe := a.universe(field.ValueType, a.isNullable(field.Val))
+	if universe == nil {
+		return "", nil, false
+	}
+
+	var values []any
+	switch operator {
+	case InOperator, NotInOperator:
+		valueSet, ok := constant.typedValue.(set[any])
+		if !ok {
+			return "", nil, false
+		}
+		values = valueSet.Elements()
+	case GreaterOperator, GreaterEqualOperator, LessOperator, LessEqualOperator, EqualOperator, NotEqualOperator:
+		values = []any{constant.typedValue}
+	default:
+		return "", nil, false
+	}
+
+	domain, ok := universe.satisfying(operator, values)
+	if !ok {
+		return "", nil, false
+	}
+	return field.Val, domain, true
+}
+
+// operators having operands swapped
+var swappedOperators = map[string]string{
+	GreaterOperator:      LessOperator,
+	GreaterEqualOperator: LessEqualOperator,
+	LessOperator:         GreaterOperator,
+	LessEqualOperator:    GreaterEqualOperator,
+	EqualOperator:        EqualOperator,
+	NotEqualOperator:     NotEqualOperator,
+}
+
+func (a *analyzer) isNullable(fieldName string) bool {
+	option, ok := a.config.FieldOptions[fieldName]
+	return ok && option.Nullable
+}
+
+// returns all values of the valueType including missing field if nullable, nil if valueType can not be analyzed
+func (a *analyzer) universe(valueType ValueType, nullable bool) fieldDomain {
+	switch valueType {
+	case Integer, Duration, DateTime:
+		return &rangeSet[int64]{spans: []span[int64]{unboundedSpan[int64]()}, missing: nullable, nullable: nullable}
+	case Float:
+		return &rangeSet[float64]{spans: []span[float64]{unboundedSpan[float64]()}, missing: nullable, nullable: nullable}
+	case String:
+		return &discreteSet{cofinite: true, values: map[any]bool{}, missing: nullable, nullable: nullable}
+	case Boolean:
+		return &discreteSet{cofinite: true, values: map[any]bool{}, universe: []any{true, false}, missing: nullable,
+			nullable: nullable}
+	}
+	return nil
+}
+
+// 'box' is a set of inputs, each field having value within respective domain, field not in box can have any value
+type box map[string]fieldDomain
+
+// returns non-empty intersections of boxes, fails if number of boxes exceeds 'maxAnalysisBoxes'
+func intersectBoxes(first, second []box) ([]box, bool) {
+	ret := []box{}
+	for _, a := range first {
+		for _, b := range second {
+			if intersection, ok := a.intersect(b); ok {
+				ret = append(ret, intersection)
+				if len(ret) > maxAnalysisBoxes {
+					return nil, false
+				}
+			}
+		}
+	}
+	return ret, true
+}
+
+// returns intersection of boxes, false if intersection is empty
+func (b box) intersect(other box) (box, bool) {
+	ret := make(box, len(b)+len(other))
+	for field, domain := range b {
+		ret[field] = domain
+	}
+	for field, domain := range other {
+		if existing, ok := ret[field]; ok {
+			domain = existing.intersect(domain)
+			if domain.isEmpty() {
+				return nil, false
+			}
+		}
+		ret[field] = domain
+	}
+	return ret, true
+}
+
+// 'fieldDomain' is a set of values of a field, including missing field for nullable field
+type fieldDomain interface {
+	// other is expected to be domain of the same field
+	intersect(other fieldDomain) fieldDomain
+	complement() fieldDomain
+	isEmpty() bool
+
+	// returns domain of values satisfying the operator for any of the values, missing field satisfies no operator
+	satisfying(operator string, values []any) (fieldDomain, bool)
+
+	// returns copy of domain having missing field excluded if 'exclude' is true
+	withoutMissing(exclude bool) fieldDomain
+}
+
+// 'span' is a non-empty interval of values
+type span[T int64 | float64] struct {
+	lower bound[T]
+	upper bound[T]
+}
+
+func unboundedSpan[T int64 | float64]() span[T] {
+	return span[T]{lower: bound[T]{unbounded: true}, upper: bound[T]{unbounded: true}}
+}
+
+// 'rangeSet' is a set of numeric values as sorted disjoint spans, Integer, Duration and DateTime (as unix nano) values
+// are considered as int64, hence bounds are kept inclusive
+type rangeSet[T int64 | float64] struct {
+	spans    []span[T]
+	missing  bool
+	nullable bool
+}
+
+func (r *rangeSet[T]) intersect(other fieldDomain) fieldDomain {
+	o := other.(*rangeSet[T])
+	ret := &rangeSet[T]{missing: r.missing && o.missing, nullable: r.nullable}
+	for _, a := range r.spans {
+		for _, b := range o.spans {
+			lower, upper := a.lower, a.upper
+			if lowerIsTighter(b.lower, lower) {
+				lower = b.lower
+			}
+			if upperIsTighter(b.upper, upper) {
+				upper = b.upper
+			}
+			ret.add(lower, upper)
+		}
+	}
+	sort.Slice(ret.spans, func(i, j int) bool {
+		return lowerIsTighter(ret.spans[j].lower, ret.spans[i].lower)
+	})
+	return ret
+}
+
+func (r *rangeSet[T]) complement() fieldDomain {
+	ret := &rangeSet[T]{missing: r.nullable && !r.missing, nullable: r.nullable}
+	lower := bound[T]{unbounded: true}
+	for _, s := range r.spans {
+		if !s.lower.unbounded {
+			ret.add(lower, bound[T]{value: s.lower.value, inclusive: !s.lower.inclusive})
+		}
+		if s.upper.unbounded {
+			return ret
+		}
+		lower = bound[T]{value: s.upper.value, inclusive: !s.upper.inclusive}
+	}
+	ret.add(lower, bound[T]{unbounded: true})
+	return ret
+}
+
+func (r *rangeSet[T]) isEmpty() bool {
+	return len(r.spans) == 0 && !r.missing
+}
+
+func (r *rangeSet[T]) satisfying(operator string, values []any) (fieldDomain, bool) {
+	// values other than the values of 'in', field is expected to be present
+	if operator == NotEqualOperator || operator == NotInOperator {
+		in, ok := r.satisfying(InOperator, values)
+		if !ok {
+			return nil, false
+		}
+		return in.complement().withoutMissing(true), true
+	}
+
+	ret := &rangeSet[T]{nullable: r.nullable}
+	for _, val := range values {
+		value, ok := rangeValue[T](val)
+		if !ok {
+			return nil, false
+		}
+
+		unbounded := bound[T]{unbounded: true}
+		switch operator {
+		case GreaterOperator, GreaterEqualOperator:
+			ret.add(bound[T]{value: value, inclusive: operator == GreaterEqualOperator}, unbounded)
+		case LessOperator, LessEqualOperator:
+			ret.add(unbounded, bound[T]{value: value, inclusive: operator == LessEqualOperator})
+		case EqualOperator, InOperator:
+			ret.add(bound[T]{value: value, inclusive: true}, bound[T]{value: value, inclusive: true})
+		default:
+			return nil, false
+		}
+	}
+
+	// multiple values of 'in' are union of values
+	if operator == InOperator {
+		return ret.union(), true
+	}
+	return ret, true
+}
+
+func (r *rangeSet[T]) withoutMissing(exclude bool) fieldDomain {
+	return &rangeSet[T]{spans: r.spans, missing: r.missing && !exclude, nullable: r.nullable}
+}
+
+// returns set having overlapping spans merged
+func (r *rangeSet[T]) union() *rangeSet[T] {
+	ret := &rangeSet[T]{missing: r.missing, nullable: r.nullable}
+	for _, s := range r.spans {
+		ret = ret.complement().intersect((&rangeSet[T]{spans: []span[T]{s}, nullable: r.nullable}).complement()).
+			complement().(*rangeSet[T])
+	}
+	return ret
+}
+
+// adds span if not empty, int64 bounds are made inclusive
+func (r *rangeSet[T]) add(lower, upper bound[T]) {
+	if _, discrete := any(lower.value).(int64); discrete {
+		var ok bool
+		if lower, ok = inclusiveBound(lower, 1); !ok {
+			return
+		}
+		if upper, ok = inclusiveBound(upper, -1); !ok {
+			return
+		}
+	}
+
+	if !lower.unbounded && !upper.unbounded {
+		if lower.value > upper.value || (lower.value == upper.value && !(lower.inclusive && upper.inclusive)) {
+			return
+		}
+	}
+	r.spans = append(r.spans, span[T]{lower: lower, upper: upper})
+}
+
+// returns inclusive bound of int64 exclusive bound, next value towards 'direction' is the inclusive bound
+func inclusiveBound[T int64 | float64](b bound[T], direction int64) (bound[T], bool) {
+	if b.unbounded || b.inclusive {
+		return b, true
+	}
+	value := any(b.value).(int64)
+	if (direction > 0 && value == math.MaxInt64) || (direction < 0 && value == math.MinInt64) {
+		return b, false
+	}
+	return bound[T]{value: T(value + direction), inclusive: true}, true
+}
+
+// reports whether lower bound 'a' is higher than 'b'
+func lowerIsTighter[T int64 | float64](a, b bound[T]) bool {
+	if a.unbounded || b.unbounded {
+		return !a.unbounded && b.unbounded
+	}
+	return a.value > b.value || (a.value == b.value && !a.inclusive && b.inclusive)
+}
+
+// reports whether upper bound 'a' is lower than 'b'
+func upperIsTighter[T int64 | float64](a, b bound[T]) bool {
+	if a.unbounded || b.unbounded {
+		return !a.unbounded && b.unbounded
+	}
+	return a.value < b.value || (a.value == b.value && !a.inclusive && b.inclusive)
+}
+
+// returns typed constant value as value of the range
+func rangeValue[T int64 | float64](val any) (T, bool) {
+	var ret any
+	switch v := val.(type) {
+	case int64, float64:
+		ret = v
+	case time.Duration:
+		ret = int64(v)
+	case time.Time:
+		ret = v.UnixNano()
+	}
+	typed, ok := ret.(T)
+	return typed, ok
+}
+
+// 'discreteSet' is a set of String or Boolean values, either finite set of values or all values except the finite set
+// of values if 'cofinite'. 'universe' is set of all possible values, nil for infinite set of values.
+type discreteSet struct {
+	values   map[any]bool
+	cofinite bool
+	universe []any
+	missing  bool
+	nullable bool
+}
+
+func (d *discreteSet) intersect(other fieldDomain) fieldDomain {
+	o := other.(*discreteSet)
+	ret := &discreteSet{values: map[any]bool{}, universe: d.universe, missing: d.missing && o.missing, nullable: d.nullable}
+	switch {
+	case d.cofinite && o.cofinite:
+		// all values except values of either set
+		ret.cofinite = true
+		for _, set := range []map[any]bool{d.values, o.values} {
+			for val := range set {
+				ret.values[val] = true
+			}
+		}
+	case d.cofinite || o.cofinite:
+		finite, excluded := d.values, o.values
+		if d.cofinite {
+			finite, excluded = o.values, d.values
+		}
+		for val := range finite {
+			if !excluded[val] {
+				ret.values[val] = true
+			}
+		}
+	default:
+		for val := range d.values {
+			if o.values[val] {
+				ret.values[val] = true
+			}
+		}
+	}
+	return ret
+}
+
+func (d *discreteSet) complement() fieldDomain {
+	return &discreteSet{values: d.values, cofinite: !d.cofinite, universe: d.universe,
+		missing: d.nullable && !d.missing, nullable: d.nullable}
+}
+
+func (d *discreteSet) isEmpty() bool {
+	if d.missing {
+		return false
+	}
+	if !d.cofinite {
+		return len(d.values) == 0
+	}
+	if d.universe == nil {
+		return false
+	}
+	for _, val := range d.universe {
+		if !d.values[val] {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *discreteSet) satisfying(operator string, values []any) (fieldDomain, bool) {
+	ret := &discreteSet{values: map[any]bool{}, universe: d.universe, nullable: d.nullable}
+	for _, val := range values {
+		ret.values[val] = true
+	}
+
+	switch operator {
+	case EqualOperator, InOperator:
+	case NotEqualOperator, NotInOperator:
+		ret.cofinite = true
+	default:
+		return nil, false
+	}
+	return ret, true
+}
+
+func (d *discreteSet) withoutMissing(exclude bool) fieldDomain {
+	return &discreteSet{values: d.values, cofinite: d.cofinite, universe: d.universe, missing: d.missing && !exclude,
+		nullable: d.nullable}
+}
@@ -0,0 +1,221 @@
+package ruleenginecore
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func testAnalyzerConfig(rules map[string]*Condition) *RuleEngineConfig {
+	comparison := func(operator string, valueType ValueType, field string, value string) *ConditionType {
+		return &ConditionType{Operator: operator, Operands: []*Operand{
+			{Type: Field, ValueType: valueType, Val: field},
+			{Type: Constant, ValueType: valueType, Val: value},
+		}}
+	}
+	presence := func(operator string, valueType ValueType, field string) *ConditionType {
+		return &ConditionType{Operator: operator, Operands: []*Operand{{Type: Field, ValueType: valueType, Val: field}}}
+	}
+
+	config := &RuleEngineConfig{
+		Fields: Fields{"amount": Integer, "price": Float, "city": String, "premium": Boolean, "discount": Integer},
+		FieldOptions: map[string]*FieldOption{
+			"discount": {Nullable: true},
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"amountMoreThan100":   comparison(GreaterOperator, Integer, "amount", "100"),
+			"amountMoreThan50":    comparison(GreaterOperator, Integer, "amount", "50"),
+			"amountAtMost100":     comparison(LessEqualOperator, Integer, "amount", "100"),
+			"amountLessThan50":    comparison(LessOperator, Integer, "amount", "50"),
+			"amountMoreThan5":     comparison(GreaterOperator, Integer, "amount", "5"),
+			"amountLessThan6":     comparison(LessOperator, Integer, "amount", "6"),
+			"priceMoreThan5":      comparison(GreaterOperator, Float, "price", "5"),
+			"priceLessThan6":      comparison(LessOperator, Float, "price", "6"),
+			"cityMetro":           comparison(InOperator, String, "city", "Delhi,Mumbai"),
+			"cityNotDelhi":        comparison(NotInOperator, String, "city", "Delhi"),
+			"cityDelhi":           comparison(EqualOperator, String, "city", "Delhi"),
+			"cityPune":            comparison(EqualOperator, String, "city", "Pune"),
+			"cityContainsA":       comparison(ContainOperator, String, "city", "a"),
+			"premium":             comparison(EqualOperator, Boolean, "premium", "true"),
+			"notPremium":          comparison(NotEqualOperator, Boolean, "premium", "true"),
+			"discountMoreThan10":  comparison(GreaterOperator, Integer, "discount", "10"),
+			"discountAtMost10":    comparison(LessEqualOperator, Integer, "discount", "10"),
+			"discountPresent":     presence(IsPresentOperator, Integer, "discount"),
+			"discountMissing":     presence(IsMissingOperator, Integer, "discount"),
+			"amountMissing":       presence(IsMissingOperator, Integer, "amount"),
+			"amountMax":           comparison(EqualOperator, Integer, "amount", "9223372036854775807"),
+			"amountNotMax":        comparison(NotEqualOperator, Integer, "amount", "9223372036854775807"),
+			"amountNotMin":        comparison(NotEqualOperator, Integer, "amount", "-9223372036854775808"),
+			"amountNotInBounds":   comparison(NotInOperator, Integer, "amount", "-9223372036854775808,9223372036854775807"),
+			"hundredLessThanAmnt": {Operator: LessOperator, Operands: []*Operand{{Type: Constant, ValueType: Integer, Val: "100"}, {Type: Field, ValueType: Integer, Val: "amount"}}},
+		},
+		Rules: map[string]*RuleConfig{},
+	}
+	for name, condition := range rules {
+		config.Rules[name] = &RuleConfig{Priority: 1, RootCondition: condition}
+	}
+	return config
+}
+
+func testCondition(conditionType string, subConditions ...*Condition) *Condition {
+	return &Condition{Type: conditionType, SubConditions: subConditions}
+}
+
+func TestAnalyze(t *testing.T) {
+	type diagnostic struct {
+		kind DiagnosticKind
+		path string
+	}
+	tests := []struct {
+		name      string
+		condition *Condition
+		want      []diagnostic
+	}{
+		{
+			name:      "Satisfiable",
+			condition: testCondition(AndCondition, testCondition("amountMoreThan50"), testCondition("amountAtMost100")),
+			want:      []diagnostic{},
+		},
+		{
+			name:      "Unsatisfiable_Range",
+			condition: testCondition(AndCondition, testCondition("amountMoreThan100"), testCondition("amountLessThan50")),
+			want:      []diagnostic{{UnsatisfiableRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Unsatisfiable_IntegerRange",
+			condition: testCondition(AndCondition, testCondition("amountMoreThan5"), testCondition("amountLessThan6")),
+			want:      []diagnostic{{UnsatisfiableRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Satisfiable_FloatRange",
+			condition: testCondition(AndCondition, testCondition("priceMoreThan5"), testCondition("priceLessThan6")),
+			want:      []diagnostic{},
+		},
+		{
+			name:      "Unsatisfiable_Set",
+			condition: testCondition(AndCondition, testCondition("cityMetro"), testCondition("cityPune")),
+			want:      []diagnostic{{UnsatisfiableRule, `rules["rule"].condition`}},
+		},
+		{
+			name: "Unsatisfiable_Negation",
+			condition: testCondition(AndCondition, testCondition("cityContainsA"),
+				testCondition(NegationCondition, testCondition("cityContainsA"))),
+			want: []diagnostic{{UnsatisfiableRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Unsatisfiable_MandatoryFieldMissing",
+			condition: testCondition("amountMissing"),
+			want:      []diagnostic{{UnsatisfiableRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Satisfiable_NotEqualMaxInteger",
+			condition: testCondition("amountNotMax"),
+			want:      []diagnostic{},
+		},
+		{
+			name:      "Satisfiable_NotEqualMinInteger",
+			condition: testCondition("amountNotMin"),
+			want:      []diagnostic{},
+		},
+		{
+			name:      "Unsatisfiable_NotEqualMaxInteger",
+			condition: testCondition(AndCondition, testCondition("amountNotMax"), testCondition("amountMax")),
+			want:      []diagnostic{{UnsatisfiableRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Unsatisfiable_NotInBoundsInteger",
+			condition: testCondition(AndCondition, testCondition("amountNotInBounds"), testCondition("amountMax")),
+			want:      []diagnostic{{UnsatisfiableRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Tautological_NotEqualMaxInteger",
+			condition: testCondition(OrCondition, testCondition("amountNotMax"), testCondition("amountMax")),
+			want:      []diagnostic{{TautologicalRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Tautological_Range",
+			condition: testCondition(OrCondition, testCondition("amountMoreThan100"), testCondition("amountAtMost100")),
+			want:      []diagnostic{{TautologicalRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Tautological_Set",
+			condition: testCondition(OrCondition, testCondition("cityNotDelhi"), testCondition("cityDelhi")),
+			want:      []diagnostic{{TautologicalRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Tautological_Boolean",
+			condition: testCondition(OrCondition, testCondition("premium"), testCondition("notPremium")),
+			want:      []diagnostic{{TautologicalRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Satisfiable_NullableRange",
+			condition: testCondition(OrCondition, testCondition("discountMoreThan10"), testCondition("discountAtMost10")),
+			want:      []diagnostic{},
+		},
+		{
+			name: "Tautological_NullableRange",
+			condition: testCondition(OrCondition, testCondition("discountMoreThan10"), testCondition("discountAtMost10"),
+				testCondition("discountMissing")),
+			want: []diagnostic{{TautologicalRule, `rules["rule"].condition`}},
+		},
+		{
+			name: "Tautological_NegatedNullableRange",
+			condition: testCondition(OrCondition, testCondition("discountMoreThan10"),
+				testCondition(NegationCondition, testCondition("discountMoreThan10"))),
+			want: []diagnostic{{TautologicalRule, `rules["rule"].condition`}},
+		},
+		{
+			name:      "Unsatisfiable_Presence",
+			condition: testCondition(AndCondition, testCondition("discountPresent"), testCondition("discountMissing")),
+			want:      []diagnostic{{UnsatisfiableRule, `rules["rule"].condition`}},
+		},
+		{
+			name: "Redundant_And",
+			condition: testCondition(AndCondition, testCondition("amountMoreThan100"), testCondition("amountMoreThan50"),
+				testCondition("cityDelhi")),
+			want: []diagnostic{{RedundantCondition, `rules["rule"].condition.subConditions[1]`}},
+		},
+		{
+			name:      "Redundant_Or",
+			condition: testCondition(OrCondition, testCondition("amountMoreThan100"), testCondition("amountMoreThan50")),
+			want:      []diagnostic{{RedundantCondition, `rules["rule"].condition.subConditions[0]`}},
+		},
+		{
+			name:      "Redundant_SwappedOperands",
+			condition: testCondition(AndCondition, testCondition("amountMoreThan100"), testCondition("hundredLessThanAmnt")),
+			want:      []diagnostic{{RedundantCondition, `rules["rule"].condition.subConditions[0]`}},
+		},
+		{
+			name: "Redundant_Nested",
+			condition: testCondition(OrCondition, testCondition("cityPune"),
+				testCondition(AndCondition, testCondition("cityContainsA"), testCondition("cityContainsA"))),
+			want: []diagnostic{{RedundantCondition, `rules["rule"].condition.subConditions[1].subConditions[0]`}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := Analyze(testAnalyzerConfig(map[string]*Condition{"rule": tt.condition}))
+			if gotErr != nil {
+				t.Fatalf("Analyze() gotErr %v", gotErr)
+			}
+
+			gotDiagnostics := []diagnostic{}
+			for _, d := range got {
+				if d.RuleName != "rule" {
+					t.Errorf("Analyze() got RuleName %v, want rule", d.RuleName)
+				}
+				gotDiagnostics = append(gotDiagnostics, diagnostic{d.Kind, d.Path})
+			}
+			if !reflect.DeepEqual(gotDiagnostics, tt.want) {
+				t.Errorf("Analyze() got %v, want %v", gotDiagnostics, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyze_InvalidConfig(t *testing.T) {
+	config := testAnalyzerConfig(map[string]*Condition{"rule": testCondition("unknown")})
+	if _, gotErr := Analyze(config); !errors.Is(gotErr, ErrConditionTypeNotFound) {
+		t.Errorf("Analyze() gotErr %v, wantErr %v", gotErr, ErrConditionTypeNotFound)
+	}
+}
@@ -3,8 +3,11 @@ package benchmark
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"strconv"
 	"testing"
 
 	ruleenginecore "github.com/niharrathod/ruleengine-core"
@@ -17,14 +20,20 @@ var ruleEngine_1000_Rule ruleenginecore.RuleEngine
 var validInput = ruleenginecore.Input{}
 var invalidInput = ruleenginecore.Input{}
 
+var validTypedInput = ruleenginecore.TypedInput{}
+var invalidTypedInput = ruleenginecore.TypedInput{}
+
 var resultTemp any
 
 func init() {
-	ruleEngine_10_Rule = prepareRuleEngine("generator/ruleEngineConfig_10.json")
-	ruleEngine_100_Rule = prepareRuleEngine("generator/ruleEngineConfig_100.json")
-	ruleEngine_1000_Rule = prepareRuleEngine("generator/ruleEngineConfig_1000.json")
+	ruleEngine_10_Rule = prepareRuleEngine("generator/ruleEngineConfig_10.json", 1)
+	ruleEngine_100_Rule = prepareRuleEngine("generator/ruleEngineConfig_100.json", 1)
+	// rules of 100 rule config are repeated, so that inputs generated along with the config are applicable
+	ruleEngine_1000_Rule = prepareRuleEngine("generator/ruleEngineConfig_100.json", 10)
 	validInput = prepareInput("generator/ValidInput.json")
 	invalidInput = prepareInput("generator/InvalidInput.json")
+	validTypedInput = prepareTypedInput("generator/ruleEngineConfig_10.json", validInput)
+	invalidTypedInput = prepareTypedInput("generator/ruleEngineConfig_10.json", invalidInput)
 }
 
 func Benchmark_RuleEngine_10_Rule_EvaluateComplete(b *testing.B) {
@@ -47,6 +56,7 @@ func Benchmark_RuleEngine_10_Rule_EvaluateComplete(b *testing.B) {
 }
 
 func Benchmark_RuleEngine_100_Rule_EvaluateComplete(b *testing.B) {
+	skipIfNotGenerated(b, ruleEngine_100_Rule)
 	var r any
 
 	b.Run("validInput", func(b *testing.B) {
@@ -65,6 +75,7 @@ func Benchmark_RuleEngine_100_Rule_EvaluateComplete(b *testing.B) {
 }
 
 func Benchmark_RuleEngine_1000_Rule_EvaluateComplete(b *testing.B) {
+	skipIfNotGenerated(b, ruleEngine_1000_Rule)
 	var r any
 
 	b.Run("validInput", func(b *testing.B) {
@@ -83,17 +94,91 @@ func Benchmark_RuleEngine_1000_Rule_EvaluateComplete(b *testing.B) {
 
 }
 
-func prepareRuleEngine(configJsonFile string) ruleenginecore.RuleEngine {
+func Benchmark_RuleEngine_1000_Rule_EvaluateCompleteParallel(b *testing.B) {
+	skipIfNotGenerated(b, ruleEngine_1000_Rule)
+	var r any
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r, _ = ruleEngine_1000_Rule.Evaluate(context.TODO(), validInput, ruleenginecore.EvaluateOptions().Complete())
+		}
+	})
+	resultTemp = r
+
+	for _, workers := range []int{2, 4, 8} {
+		op := ruleenginecore.EvaluateOptions().Complete().WithParallel(workers)
+		b.Run("parallel_"+strconv.Itoa(workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				r, _ = ruleEngine_1000_Rule.Evaluate(context.TODO(), validInput, op)
+			}
+		})
+		resultTemp = r
+	}
+}
+
+func Benchmark_RuleEngine_10_Rule_EvaluateTypedComplete(b *testing.B) {
+	var r any
+
+	b.Run("validInput", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r, _ = ruleEngine_10_Rule.EvaluateTyped(context.TODO(), validTypedInput, ruleenginecore.EvaluateOptions().Complete())
+		}
+	})
+	resultTemp = r
+
+	b.Run("invalidInput", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r, _ = ruleEngine_10_Rule.EvaluateTyped(context.TODO(), invalidTypedInput, ruleenginecore.EvaluateOptions().Complete())
+		}
+	})
+	resultTemp = r
+}
+
+func Benchmark_RuleEngine_100_Rule_EvaluateTypedComplete(b *testing.B) {
+	skipIfNotGenerated(b, ruleEngine_100_Rule)
+	var r any
+
+	b.Run("validInput", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r, _ = ruleEngine_100_Rule.EvaluateTyped(context.TODO(), validTypedInput, ruleenginecore.EvaluateOptions().Complete())
+		}
+	})
+	resultTemp = r
+
+	b.Run("invalidInput", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r, _ = ruleEngine_100_Rule.EvaluateTyped(context.TODO(), invalidTypedInput, ruleenginecore.EvaluateOptions().Complete())
+		}
+	})
+	resultTemp = r
+}
+
+// returns engine having rules of the config repeated 'copies' times, nil if the config file is not generated,
+// benchmarks of such engine are skipped
+func prepareRuleEngine(configJsonFile string, copies int) ruleenginecore.RuleEngine {
 	wd, _ := os.Getwd()
-	valBytes, _ := os.ReadFile(wd + "/" + configJsonFile)
+	valBytes, err := os.ReadFile(wd + "/" + configJsonFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
 	var engineConfig ruleenginecore.RuleEngineConfig
-	err := json.Unmarshal(valBytes, &engineConfig)
+	err = json.Unmarshal(valBytes, &engineConfig)
 
 	if err != nil {
 		fmt.Println("json unmarshal failed : ", err.Error())
 		panic("No")
 	}
 
+	rules := engineConfig.Rules
+	engineConfig.Rules = map[string]*ruleenginecore.RuleConfig{}
+	for ruleName, rule := range rules {
+		for i := 0; i < copies; i++ {
+			ruleCopy := *rule
+			ruleCopy.Priority = rule.Priority*copies + i
+			engineConfig.Rules[ruleName+"_"+strconv.Itoa(i)] = &ruleCopy
+		}
+	}
+
 	engine, reErr := ruleenginecore.New(&engineConfig)
 
 	if reErr != nil {
@@ -104,6 +189,12 @@ func prepareRuleEngine(configJsonFile string) ruleenginecore.RuleEngine {
 	return engine
 }
 
+func skipIfNotGenerated(b *testing.B, engine ruleenginecore.RuleEngine) {
+	if engine == nil {
+		b.Skip("rule engine config is not generated, see generator/main.go")
+	}
+}
+
 func prepareInput(filename string) ruleenginecore.Input {
 	input := ruleenginecore.Input{}
 
@@ -121,3 +212,32 @@ func readJsonFile(filename string) []byte {
 	valBytes, _ := os.ReadFile(wd + "/" + filename)
 	return valBytes
 }
+
+// converts string input to typed input as per fields defined with the config
+func prepareTypedInput(configJsonFile string, input ruleenginecore.Input) ruleenginecore.TypedInput {
+	var engineConfig ruleenginecore.RuleEngineConfig
+	if err := json.Unmarshal(readJsonFile(configJsonFile), &engineConfig); err != nil {
+		fmt.Println(configJsonFile+" json unmarshal failed : ", err.Error())
+		panic("No")
+	}
+
+	result := ruleenginecore.TypedInput{}
+	for name, valueType := range engineConfig.Fields {
+		var err error
+		switch valueType {
+		case ruleenginecore.Integer:
+			result[name], err = strconv.ParseInt(input[name], 10, 64)
+		case ruleenginecore.Float:
+			result[name], err = strconv.ParseFloat(input[name], 64)
+		case ruleenginecore.Boolean:
+			result[name], err = strconv.ParseBool(input[name])
+		default:
+			result[name] = input[name]
+		}
+		if err != nil {
+			fmt.Println("typed input preparation failed : ", err.Error())
+			panic("No")
+		}
+	}
+	return result
+}
@@ -0,0 +1,227 @@
+package ruleenginecore
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// struct tag name to bind struct field with RuleEngine field, ex. `rule:"totalAmount"`
+const structTagName = "rule"
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// accessor gets typed value of a struct field
+type fieldAccessor func(v reflect.Value) any
+
+type boundField struct {
+	name     string
+	slot     int
+	index    []int
+	accessor fieldAccessor
+}
+
+// 'structBinding' binds struct fields having 'rule' tag with RuleEngine fields, prepared once per struct type
+type structBinding struct {
+	fields []*boundField
+
+	// mandatory fields not bound to any struct field, struct is valid input only if no rule refers such fields
+	unbound []*boundField
+}
+
+// maintains struct bindings per struct type
+type structBindingCache struct {
+	bindings sync.Map
+}
+
+func (re *ruleEngine) parseStruct(sb *structBinding, v reflect.Value) (*slotInput, *RuleEngineError) {
+	for _, field := range sb.unbound {
+		if re.isReferenced(field.slot) {
+			return nil, newError(ErrCodeFieldNotFound,
+				fmt.Sprintf("Expecting struct field with tag `%v:\"%v\"` having valueType: %v", structTagName, field.name,
+					re.fields[field.name])).withField(field.name)
+		}
+	}
+
+	ret := re.newSlotInput()
+	for _, field := range sb.fields {
+		fieldValue, err := v.FieldByIndexErr(field.index)
+		if err != nil {
+			return nil, newError(ErrCodeFieldNotFound,
+				fmt.Sprintf("Could not get value for field: %v", field.name)).withField(field.name).wrap(err)
+		}
+
+		// nil pointer struct field is considered as missing field
+		ret.slots[field.slot] = field.accessor(fieldValue)
+	}
+
+	if err := re.resolveMissingFields(ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func newStructBinding(t reflect.Type, fs Fields, fieldOptions map[string]*FieldOption) (*structBinding, *RuleEngineError) {
+	binding := &structBinding{fields: []*boundField{}}
+	bound := NewSet[string]()
+	fieldSlots := newFieldSlots(fs)
+
+	for _, structField := range reflect.VisibleFields(t) {
+		name, ok := structField.Tag.Lookup(structTagName)
+		if !ok || name == "-" {
+			continue
+		}
+
+		if !structField.IsExported() {
+			return nil, newError(ErrCodeInvalidStructBinding,
+				fmt.Sprintf("Struct field: %v is not exported", structField.Name))
+		}
+
+		valueType, ok := fs[name]
+		if !ok {
+			return nil, newError(ErrCodeInvalidStructBinding,
+				fmt.Sprintf("Struct field: %v is bound to unknown field: %v", structField.Name, name)).withField(name)
+		}
+
+		if bound.Contains(name) {
+			return nil, newError(ErrCodeInvalidStructBinding,
+				fmt.Sprintf("Field: %v is bound to multiple struct fields", name)).withField(name)
+		}
+
+		accessor, ok := newFieldAccessor(structField.Type, valueType)
+		if !ok {
+			return nil, newError(ErrCodeInvalidStructBinding,
+				fmt.Sprintf("Struct field: %v of type %v can not be bound to field: %v having valueType: %v",
+					structField.Name, structField.Type, name, valueType)).withField(name)
+		}
+
+		bound.Add(name)
+		binding.fields = append(binding.fields, &boundField{
+			name:     name,
+			slot:     fieldSlots[name],
+			index:    structField.Index,
+			accessor: accessor,
+		})
+	}
+
+	// unbound fields are checked while parsing, as fields referred by rules differ across updated engines
+	for _, fieldName := range sortedKeys(fs) {
+		if _, optional := fieldOptions[fieldName]; !optional && !bound.Contains(fieldName) {
+			binding.unbound = append(binding.unbound, &boundField{name: fieldName, slot: fieldSlots[fieldName]})
+		}
+	}
+
+	return binding, nil
+}
+
+// prepares accessor to get typed value of struct field as per valueType, returns false if struct field type is incompatible.
+// Pointer struct field is supported, accessor gets nil for nil pointer.
+func newFieldAccessor(t reflect.Type, valueType ValueType) (fieldAccessor, bool) {
+	if t.Kind() == reflect.Pointer {
+		elementAccessor, ok := newFieldAccessor(t.Elem(), valueType)
+		if !ok {
+			return nil, false
+		}
+		return func(v reflect.Value) any {
+			if v.IsNil() {
+				return nil
+			}
+			return elementAccessor(v.Elem())
+		}, true
+	}
+
+	switch valueType {
+	case DateTime:
+		if t != timeType {
+			return nil, false
+		}
+		return func(v reflect.Value) any { return v.Interface().(time.Time) }, true
+	case Duration:
+		if t != durationType {
+			return nil, false
+		}
+		return func(v reflect.Value) any { return time.Duration(v.Int()) }, true
+	case BooleanList, StringList, IntegerList, FloatList:
+		if t.Kind() != reflect.Slice {
+			return nil, false
+		}
+		elementAccessor, ok := newFieldAccessor(t.Elem(), valueType.elementType())
+		if !ok {
+			return nil, false
+		}
+		return func(v reflect.Value) any {
+			result := make([]any, v.Len())
+			for i := range result {
+				result[i] = elementAccessor(v.Index(i))
+			}
+			return result
+		}, true
+	}
+
+	if t == timeType || t == durationType {
+		return nil, false
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if valueType == Boolean {
+			return func(v reflect.Value) any { return v.Bool() }, true
+		}
+	case reflect.String:
+		if valueType == String {
+			return func(v reflect.Value) any { return v.String() }, true
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if valueType == Integer {
+			return func(v reflect.Value) any { return v.Int() }, true
+		}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		if valueType == Integer {
+			return func(v reflect.Value) any { return int64(v.Uint()) }, true
+		}
+	case reflect.Float32, reflect.Float64:
+		if valueType == Float {
+			return func(v reflect.Value) any { return v.Float() }, true
+		}
+	}
+	return nil, false
+}
+
+// gets struct binding for the type, binding is prepared once and cached for the type
+func (c *structBindingCache) get(t reflect.Type, fs Fields, fieldOptions map[string]*FieldOption) (*structBinding, *RuleEngineError) {
+	if binding, ok := c.bindings.Load(t); ok {
+		return binding.(*structBinding), nil
+	}
+
+	binding, err := newStructBinding(t, fs, fieldOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := c.bindings.LoadOrStore(t, binding)
+	return actual.(*structBinding), nil
+}
+
+func (re *ruleEngine) validateAndParseStruct(input any) (*slotInput, *RuleEngineError) {
+	v := reflect.ValueOf(input)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, newError(ErrCodeInvalidStructBinding, "Expecting non-nil struct input")
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, newError(ErrCodeInvalidStructBinding, fmt.Sprintf("Expecting struct input, got %T", input))
+	}
+
+	binding, err := re.bindings.get(v.Type(), re.fields, re.fieldOptions)
+	if err != nil {
+		return nil, err
+	}
+	return re.parseStruct(binding, v)
+}
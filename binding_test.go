@@ -0,0 +1,260 @@
+package ruleenginecore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testBookingDetail struct {
+	PaxCount int `rule:"PaxCount"`
+}
+
+type testBooking struct {
+	testBookingDetail
+	Amount    int64     `rule:"totalAmount"`
+	Hotel     bool      `rule:"IsHotelBooking"`
+	CreatedAt time.Time `rule:"createdAt"`
+	Tags      []string  `rule:"tags"`
+	Note      string
+}
+
+func Test_ruleEngine_validateAndParseStruct(t *testing.T) {
+	fs := Fields{
+		"totalAmount":    Integer,
+		"IsHotelBooking": Boolean,
+		"PaxCount":       Integer,
+		"createdAt":      DateTime,
+		"tags":           StringList,
+	}
+	createdAt := time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		fields       Fields
+		fieldOptions map[string]*FieldOption
+		unreferenced []string
+		input        any
+		want         parsedInput
+		wantErr      *RuleEngineError
+	}{
+		{
+			name:   "valid_Struct",
+			fields: fs,
+			input: testBooking{
+				testBookingDetail: testBookingDetail{PaxCount: 2},
+				Amount:            25000,
+				Hotel:             true,
+				CreatedAt:         createdAt,
+				Tags:              []string{"family"},
+			},
+			want: parsedInput{
+				"totalAmount":    int64(25000),
+				"IsHotelBooking": true,
+				"PaxCount":       int64(2),
+				"createdAt":      createdAt,
+				"tags":           []any{"family"},
+			},
+		},
+		{
+			name:   "valid_StructPointer",
+			fields: Fields{"PaxCount": Integer},
+			input: &struct {
+				PaxCount int8 `rule:"PaxCount"`
+			}{PaxCount: 3},
+			want: parsedInput{
+				"PaxCount": int64(3),
+			},
+		},
+		{
+			name:         "valid_NullablePointerField",
+			fields:       Fields{"PaxCount": Integer, "couponCode": String},
+			fieldOptions: map[string]*FieldOption{"couponCode": {Nullable: true}},
+			input: struct {
+				PaxCount   int     `rule:"PaxCount"`
+				CouponCode *string `rule:"couponCode"`
+			}{PaxCount: 3},
+			want: parsedInput{
+				"PaxCount": int64(3),
+			},
+		},
+		{
+			name:         "valid_UnboundOptionalField",
+			fields:       Fields{"PaxCount": Integer, "couponCode": String},
+			fieldOptions: map[string]*FieldOption{"couponCode": {Default: testStringPtr(""), typedDefault: ""}},
+			input: struct {
+				PaxCount int `rule:"PaxCount"`
+			}{PaxCount: 3},
+			want: parsedInput{
+				"PaxCount":   int64(3),
+				"couponCode": "",
+			},
+		},
+		{
+			name:    "invalid_NotStruct",
+			fields:  fs,
+			input:   map[string]any{},
+			wantErr: newError(ErrCodeInvalidStructBinding),
+		},
+		{
+			name:    "invalid_NilPointer",
+			fields:  fs,
+			input:   (*testBooking)(nil),
+			wantErr: newError(ErrCodeInvalidStructBinding),
+		},
+		{
+			name:   "invalid_UnknownField",
+			fields: Fields{"PaxCount": Integer},
+			input: struct {
+				PaxCount int `rule:"PaxCount"`
+				Unknown  int `rule:"unknown"`
+			}{},
+			wantErr: newError(ErrCodeInvalidStructBinding),
+		},
+		{
+			name:   "invalid_IncompatibleType",
+			fields: Fields{"PaxCount": Integer},
+			input: struct {
+				PaxCount string `rule:"PaxCount"`
+			}{},
+			wantErr: newError(ErrCodeInvalidStructBinding),
+		},
+		{
+			name:   "invalid_FieldNotBound",
+			fields: Fields{"PaxCount": Integer, "totalAmount": Integer},
+			input: struct {
+				PaxCount int `rule:"PaxCount"`
+			}{},
+			wantErr: newError(ErrCodeFieldNotFound),
+		},
+		{
+			name:         "valid_UnboundUnreferencedField",
+			fields:       Fields{"PaxCount": Integer, "totalAmount": Integer},
+			unreferenced: []string{"totalAmount"},
+			input: struct {
+				PaxCount int `rule:"PaxCount"`
+			}{PaxCount: 3},
+			want: parsedInput{
+				"PaxCount": int64(3),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := &ruleEngine{
+				fields:       tt.fields,
+				fieldOptions: tt.fieldOptions,
+				bindings:     &structBindingCache{},
+				fieldSlots:   newFieldSlots(tt.fields),
+				slotFields:   newSlotFields(tt.fields),
+			}
+			re.fieldReferences = make([]int, len(re.slotFields))
+			for slot := range re.fieldReferences {
+				re.fieldReferences[slot] = 1
+			}
+			for _, fieldName := range tt.unreferenced {
+				re.fieldReferences[re.fieldSlots[fieldName]] = 0
+			}
+			got, gotErr := re.validateAndParseStruct(tt.input)
+			if !reflect.DeepEqual(testParsedValues(got), tt.want) {
+				t.Errorf("ruleEngine.validateAndParseStruct() got = %v, want %v", got, tt.want)
+			}
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("ruleEngine.validateAndParseStruct() gotErr = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_structBindingCache_get(t *testing.T) {
+	cache := &structBindingCache{}
+	fs := Fields{"PaxCount": Integer}
+	bookingType := reflect.TypeOf(testBookingDetail{})
+
+	first, err := cache.get(bookingType, fs, nil)
+	if err != nil {
+		t.Fatalf("structBindingCache.get() gotErr = %v", err)
+	}
+	second, err := cache.get(bookingType, fs, nil)
+	if err != nil {
+		t.Fatalf("structBindingCache.get() gotErr = %v", err)
+	}
+	if first != second {
+		t.Errorf("structBindingCache.get() expecting cached binding for same type")
+	}
+}
+
+func TestRuleEngine_EvaluateStruct(t *testing.T) {
+	engine := &ruleEngine{
+		fields: map[string]ValueType{
+			"totalAmount":    Integer,
+			"IsHotelBooking": Boolean,
+			"PaxCount":       Integer,
+		},
+		bindings:       &structBindingCache{},
+		fieldSlots:     testFieldSlots,
+		slotFields:     testSlotFields,
+		conditionCount: testConditionCount,
+		ruleMap: map[string]*rule{
+			"Discount10": discount10TestRule,
+			"Discount5":  discount5TestRule,
+		},
+		rules: []*rule{
+			discount10TestRule,
+			discount5TestRule,
+		},
+	}
+	type booking struct {
+		Amount   int64 `rule:"totalAmount"`
+		Hotel    bool  `rule:"IsHotelBooking"`
+		PaxCount int   `rule:"PaxCount"`
+	}
+	input := &booking{Amount: 25000, Hotel: true, PaxCount: 6}
+
+	got, gotErr := engine.EvaluateStruct(context.TODO(), input, EvaluateOptions().Complete())
+	if gotErr != nil {
+		t.Fatalf("ruleEngine.EvaluateStruct() gotErr = %v", gotErr)
+	}
+	want := []*Output{newOutput("Discount10", 1, discount10TestRule.result)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ruleEngine.EvaluateStruct() got = %v, want %v", got, want)
+	}
+
+	gotSingle, gotErr := engine.EvaluateSingleRuleStruct(context.TODO(), input, "Discount10")
+	if gotErr != nil || !reflect.DeepEqual(gotSingle, want[0]) {
+		t.Errorf("ruleEngine.EvaluateSingleRuleStruct() got = %v, gotErr = %v, want %v", gotSingle, gotErr, want[0])
+	}
+}
+
+func TestRuleEngine_EvaluateStruct_UnreferencedField(t *testing.T) {
+	type order struct {
+		Amount int64 `rule:"amount"`
+	}
+	input := &order{Amount: 150}
+
+	engine, err := NewUpdatable(testUpdateConfig())
+	if err != nil {
+		t.Fatalf("NewUpdatable() gotErr %v", err)
+	}
+	if _, err := engine.EvaluateStruct(context.TODO(), input, EvaluateOptions().Complete()); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("EvaluateStruct() gotErr %v, wantErr %v", err, ErrFieldNotFound)
+	}
+
+	// city is not bound, which is fine once rules having city are removed
+	for _, ruleName := range []string{"bothRule", "cityRule"} {
+		if engine, err = engine.RemoveRule(ruleName); err != nil {
+			t.Fatalf("RemoveRule() gotErr %v", err)
+		}
+	}
+	got, err := engine.EvaluateStruct(context.TODO(), input, EvaluateOptions().Complete())
+	if err != nil {
+		t.Fatalf("EvaluateStruct() gotErr %v", err)
+	}
+	if len(got) != 1 || got[0].Rulename != "amountRule" {
+		t.Errorf("EvaluateStruct() got %v, want amountRule", got)
+	}
+	if _, err := engine.EvaluateJSON(context.TODO(), []byte(`{"amount":150}`), EvaluateOptions().Complete()); err != nil {
+		t.Errorf("EvaluateJSON() gotErr %v", err)
+	}
+}
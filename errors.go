@@ -0,0 +1,219 @@
+package ruleenginecore
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 'RuleEngineError' is an error reported by the library. Errors having same 'ErrCode' match with errors.Is, and so
+// does sentinel error of the code such as 'ErrRuleNotFound', which identifies kind of an error:
+//
+//	if errors.Is(err, ruleenginecore.ErrRuleNotFound) {...}
+//
+// Details of an error are available with errors.As, 'Cause' is the underlying error if any (ex. strconv error while
+// parsing a value), which is unwrapped by errors.Is and errors.As as well.
+type RuleEngineError struct {
+	ErrCode  uint
+	ErrMsg   string
+	OtherMsg string
+
+	// name of rule, condition type and field the error is about, empty if not applicable
+	RuleName      string
+	ConditionType string
+	Field         string
+
+	Cause error
+}
+
+func (ce *RuleEngineError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "RuleEngineError: ErrCode:%v ErrMsg:%v. %v", ce.ErrCode, ce.ErrMsg, ce.OtherMsg)
+	if len(ce.RuleName) != 0 {
+		fmt.Fprintf(&b, ", RuleName: %v", ce.RuleName)
+	}
+	if len(ce.ConditionType) != 0 {
+		fmt.Fprintf(&b, ", ConditionType: %v", ce.ConditionType)
+	}
+	if len(ce.Field) != 0 {
+		fmt.Fprintf(&b, ", Field: %v", ce.Field)
+	}
+	if ce.Cause != nil {
+		fmt.Fprintf(&b, ", Cause: %v", ce.Cause)
+	}
+	return b.String()
+}
+
+func (ce *RuleEngineError) Unwrap() error {
+	return ce.Cause
+}
+
+// 'Is' reports whether target is a sentinel error or a RuleEngineError having same error code
+func (ce *RuleEngineError) Is(target error) bool {
+	switch targetErr := target.(type) {
+	case errorCode:
+		return uint(targetErr) == ce.ErrCode
+	case *RuleEngineError:
+		return targetErr.ErrCode == ce.ErrCode
+	}
+	return false
+}
+
+func (ce *RuleEngineError) addMsg(msg string) {
+	if len(ce.OtherMsg) == 0 {
+		ce.OtherMsg = msg
+		return
+	}
+	ce.OtherMsg = fmt.Sprintf("%v, %v", ce.OtherMsg, msg)
+}
+
+// sets rule name the error is about, if not set already
+func (ce *RuleEngineError) withRule(ruleName string) *RuleEngineError {
+	if len(ce.RuleName) == 0 {
+		ce.RuleName = ruleName
+	}
+	return ce
+}
+
+// sets condition type the error is about, if not set already
+func (ce *RuleEngineError) withConditionType(conditionTypeName string) *RuleEngineError {
+	if len(ce.ConditionType) == 0 {
+		ce.ConditionType = conditionTypeName
+	}
+	return ce
+}
+
+// sets field the error is about, if not set already
+func (ce *RuleEngineError) withField(fieldName string) *RuleEngineError {
+	if len(ce.Field) == 0 {
+		ce.Field = fieldName
+	}
+	return ce
+}
+
+// sets underlying error caused the error
+func (ce *RuleEngineError) wrap(cause error) *RuleEngineError {
+	ce.Cause = cause
+	return ce
+}
+
+// 'ValidationError' is a problem found while validating RuleEngineConfig, 'Path' is JSON path of the offending
+// element within RuleEngineConfig, names are quoted as they can have '.'
+// (ex. `rules["ruleX"].condition.subConditions[1]`)
+type ValidationError struct {
+	Path string           `json:"path"`
+	Err  *RuleEngineError `json:"error"`
+}
+
+func (ve *ValidationError) Error() string {
+	return fmt.Sprintf("%v: %v", ve.Path, ve.Err)
+}
+
+func (ve *ValidationError) Unwrap() error {
+	return toError(ve.Err)
+}
+
+func newValidationError(err *RuleEngineError, path string) *ValidationError {
+	return &ValidationError{Path: path, Err: err}
+}
+
+// returns JSON path of the named element of a section within RuleEngineConfig followed by 'elements', name is quoted
+// as field name can have '.' (ex. `fields["user.tier"]`)
+func configPath(section string, name string, elements ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v[%v]", section, strconv.Quote(name))
+	for _, element := range elements {
+		b.WriteString("." + element)
+	}
+	return b.String()
+}
+
+func newError(errCode uint, msgs ...string) *RuleEngineError {
+	return &RuleEngineError{ErrCode: errCode, ErrMsg: errCodeToMessage[errCode], OtherMsg: strings.Join(msgs, ",")}
+}
+
+// converts to error interface, nil error is converted to nil interface value instead of non-nil interface having nil
+// pointer
+func toError(err *RuleEngineError) error {
+	if err == nil {
+		return nil
+	}
+	return err
+}
+
+const (
+	ErrCodeInvalidValueType = iota + 1
+	ErrCodeInvalidOperator
+	ErrCodeInvalidOperandType
+	ErrCodeInvalidOperandsLength
+	ErrCodeInvalidConditionType
+	ErrCodeInvalidSubConditionCount
+	ErrCodeConditionTypeNotFound
+	ErrCodeFieldNotFound
+	ErrCodeParsingFailed
+	ErrCodeRuleNotFound
+	ErrCodeInvalidEvaluateOperations
+	ErrCodeContextCancelled
+	ErrCodeInvalidOperand
+	ErrCodeInvalidOperatorSpec
+	ErrCodeInvalidFieldName
+	ErrCodeInvalidStructBinding
+	ErrCodeInvalidFieldOption
+	ErrCodeRuleAlreadyExist
+	ErrCodeLoadConfigFailed
+	ErrCodeDuplicatePriority
+)
+
+var errCodeToMessage = map[uint]string{
+	ErrCodeInvalidValueType:          "Invalid ValueType",
+	ErrCodeInvalidOperator:           "Invalid operator",
+	ErrCodeInvalidOperandType:        "Invalid operandType",
+	ErrCodeInvalidOperandsLength:     "Invalid number of operands",
+	ErrCodeInvalidConditionType:      "Invalid conditionType",
+	ErrCodeInvalidSubConditionCount:  "Invalid sub-condition count",
+	ErrCodeConditionTypeNotFound:     "Could not find conditionType",
+	ErrCodeFieldNotFound:             "Field not found",
+	ErrCodeParsingFailed:             "Could not parse value",
+	ErrCodeRuleNotFound:              "Rule not found",
+	ErrCodeInvalidEvaluateOperations: "Invalid evaluate options value n",
+	ErrCodeContextCancelled:          "Context is cancelled",
+	ErrCodeInvalidOperand:            "Invalid operandtype or valuetype",
+	ErrCodeInvalidOperatorSpec:       "Invalid operator spec",
+	ErrCodeInvalidFieldName:          "Invalid field name",
+	ErrCodeInvalidStructBinding:      "Invalid struct binding",
+	ErrCodeInvalidFieldOption:        "Invalid field option",
+	ErrCodeRuleAlreadyExist:          "Rule already exist",
+	ErrCodeLoadConfigFailed:          "Could not load config",
+	ErrCodeDuplicatePriority:         "Duplicate rule priority",
+}
+
+// 'errorCode' is a sentinel error, which is only an error code, hence sentinel can neither be decorated nor modified
+type errorCode uint
+
+func (code errorCode) Error() string {
+	return fmt.Sprintf("RuleEngineError: ErrCode:%v ErrMsg:%v", uint(code), errCodeToMessage[uint(code)])
+}
+
+// sentinel errors for every error code, to be used with errors.Is
+var (
+	ErrInvalidValueType          error = errorCode(ErrCodeInvalidValueType)
+	ErrInvalidOperator           error = errorCode(ErrCodeInvalidOperator)
+	ErrInvalidOperandType        error = errorCode(ErrCodeInvalidOperandType)
+	ErrInvalidOperandsLength     error = errorCode(ErrCodeInvalidOperandsLength)
+	ErrInvalidConditionType      error = errorCode(ErrCodeInvalidConditionType)
+	ErrInvalidSubConditionCount  error = errorCode(ErrCodeInvalidSubConditionCount)
+	ErrConditionTypeNotFound     error = errorCode(ErrCodeConditionTypeNotFound)
+	ErrFieldNotFound             error = errorCode(ErrCodeFieldNotFound)
+	ErrParsingFailed             error = errorCode(ErrCodeParsingFailed)
+	ErrRuleNotFound              error = errorCode(ErrCodeRuleNotFound)
+	ErrInvalidEvaluateOperations error = errorCode(ErrCodeInvalidEvaluateOperations)
+	ErrContextCancelled          error = errorCode(ErrCodeContextCancelled)
+	ErrInvalidOperand            error = errorCode(ErrCodeInvalidOperand)
+	ErrInvalidOperatorSpec       error = errorCode(ErrCodeInvalidOperatorSpec)
+	ErrInvalidFieldName          error = errorCode(ErrCodeInvalidFieldName)
+	ErrInvalidStructBinding      error = errorCode(ErrCodeInvalidStructBinding)
+	ErrInvalidFieldOption        error = errorCode(ErrCodeInvalidFieldOption)
+	ErrRuleAlreadyExist          error = errorCode(ErrCodeRuleAlreadyExist)
+	ErrLoadConfigFailed          error = errorCode(ErrCodeLoadConfigFailed)
+	ErrDuplicatePriority         error = errorCode(ErrCodeDuplicatePriority)
+)
@@ -0,0 +1,137 @@
+package ruleenginecore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestRuleEngineError(t *testing.T) {
+	engine, err := NewUpdatable(testUpdateConfig())
+	if err != nil {
+		t.Fatalf("NewUpdatable() gotErr %v", err)
+	}
+	cancelled, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	tests := []struct {
+		name          string
+		evaluate      func() error
+		wantErr       error
+		wantRule      string
+		wantField     string
+		wantCondition string
+		wantCause     error
+	}{
+		{
+			name: "NoError",
+			evaluate: func() error {
+				_, err := engine.Evaluate(context.TODO(), Input{"amount": "150", "city": "Delhi"}, EvaluateOptions().Complete())
+				return err
+			},
+		},
+		{
+			name: "NoError_NotMatched",
+			evaluate: func() error {
+				_, err := engine.EvaluateSingleRule(context.TODO(), Input{"amount": "50", "city": "Pune"}, "cityRule")
+				return err
+			},
+		},
+		{
+			name: "ParsingFailed",
+			evaluate: func() error {
+				_, err := engine.Evaluate(context.TODO(), Input{"amount": "ten", "city": "Delhi"}, EvaluateOptions().Complete())
+				return err
+			},
+			wantErr:   ErrParsingFailed,
+			wantField: "amount",
+			wantCause: strconv.ErrSyntax,
+		},
+		{
+			name: "FieldNotFound",
+			evaluate: func() error {
+				_, err := engine.EvaluateTyped(context.TODO(), TypedInput{"amount": 150}, EvaluateOptions().Complete())
+				return err
+			},
+			wantErr:   ErrFieldNotFound,
+			wantField: "city",
+		},
+		{
+			name: "RuleNotFound",
+			evaluate: func() error {
+				_, err := engine.EvaluateSingleRule(context.TODO(), Input{"amount": "150", "city": "Delhi"}, "unknown")
+				return err
+			},
+			wantErr:  ErrRuleNotFound,
+			wantRule: "unknown",
+		},
+		{
+			name: "ContextCancelled",
+			evaluate: func() error {
+				_, err := engine.EvaluateSingleRule(cancelled, Input{"amount": "150", "city": "Delhi"}, "cityRule")
+				return err
+			},
+			wantErr:   ErrContextCancelled,
+			wantRule:  "cityRule",
+			wantCause: context.Canceled,
+		},
+		{
+			name: "ConditionTypeNotFound",
+			evaluate: func() error {
+				_, err := engine.AddRule("newRule", &RuleConfig{Priority: 1, RootCondition: &Condition{Type: "unknown"}})
+				return err
+			},
+			wantErr:       ErrConditionTypeNotFound,
+			wantRule:      "newRule",
+			wantCondition: "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotErr := tt.evaluate()
+			if tt.wantErr == nil {
+				// error interface is nil, not an interface having nil pointer
+				if gotErr != nil {
+					t.Errorf("gotErr %v, want nil", gotErr)
+				}
+				return
+			}
+
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Fatalf("gotErr %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			var ruleEngineErr *RuleEngineError
+			if !errors.As(gotErr, &ruleEngineErr) {
+				t.Fatalf("gotErr %v is not RuleEngineError", gotErr)
+			}
+			if ruleEngineErr.RuleName != tt.wantRule || ruleEngineErr.Field != tt.wantField ||
+				ruleEngineErr.ConditionType != tt.wantCondition {
+				t.Errorf("gotErr RuleName: %v, Field: %v, ConditionType: %v, want %v, %v, %v", ruleEngineErr.RuleName,
+					ruleEngineErr.Field, ruleEngineErr.ConditionType, tt.wantRule, tt.wantField, tt.wantCondition)
+			}
+			if tt.wantCause != nil && !errors.Is(gotErr, tt.wantCause) {
+				t.Errorf("gotErr %v, want cause %v", gotErr, tt.wantCause)
+			}
+			if errors.Is(gotErr, ErrInvalidOperator) {
+				t.Errorf("gotErr %v matches %v", gotErr, ErrInvalidOperator)
+			}
+		})
+	}
+}
+
+func TestRuleEngineError_IsSentinel(t *testing.T) {
+	err := newError(ErrCodeRuleNotFound, "rule: x").withRule("x")
+	if !errors.Is(toError(err), ErrRuleNotFound) {
+		t.Errorf("gotErr %v, wantErr %v", err, ErrRuleNotFound)
+	}
+	if errors.Is(toError(err), ErrFieldNotFound) {
+		t.Errorf("gotErr %v matches %v", err, ErrFieldNotFound)
+	}
+
+	// sentinel is only an error code, decorating an error of same code does not change it
+	wantMsg := "RuleEngineError: ErrCode:10 ErrMsg:Rule not found"
+	if got := ErrRuleNotFound.Error(); got != wantMsg {
+		t.Errorf("ErrRuleNotFound.Error() got %v, want %v", got, wantMsg)
+	}
+}
@@ -1,6 +1,10 @@
 package ruleenginecore
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"time"
+)
 
 type evaluator interface {
 	evaluate(input parsedInput) bool
@@ -47,6 +51,10 @@ func (ge *greaterEvaluator) evaluate(input parsedInput) bool {
 		return greater[int64](input, ge.operands)
 	case Float:
 		return greater[float64](input, ge.operands)
+	case Duration:
+		return greater[time.Duration](input, ge.operands)
+	case DateTime:
+		return compareTime(input, ge.operands) > 0
 	}
 	// no-op
 	panic("Invalid operandType for '" + GreaterOperator + "' operator")
@@ -60,6 +68,10 @@ func (gte *greaterEqualEvaluator) evaluate(input parsedInput) bool {
 		return greaterAndEqual[int64](input, gte.operands)
 	case Float:
 		return greaterAndEqual[float64](input, gte.operands)
+	case Duration:
+		return greaterAndEqual[time.Duration](input, gte.operands)
+	case DateTime:
+		return compareTime(input, gte.operands) >= 0
 	}
 
 	// no-op
@@ -75,6 +87,10 @@ func (lt *lessEvaluator) evaluate(input parsedInput) bool {
 		return lesser[int64](input, lt.operands)
 	case Float:
 		return lesser[float64](input, lt.operands)
+	case Duration:
+		return lesser[time.Duration](input, lt.operands)
+	case DateTime:
+		return compareTime(input, lt.operands) < 0
 	}
 
 	// no-op
@@ -89,6 +105,10 @@ func (lte *lessEqualEvaluator) evaluate(input parsedInput) bool {
 		return lesserAndEqual[int64](input, lte.operands)
 	case Float:
 		return lesserAndEqual[float64](input, lte.operands)
+	case Duration:
+		return lesserAndEqual[time.Duration](input, lte.operands)
+	case DateTime:
+		return compareTime(input, lte.operands) <= 0
 	}
 
 	// no-op
@@ -103,6 +123,10 @@ func (eq *equalEvaluator) evaluate(input parsedInput) bool {
 		return equal[int64](input, eq.operands)
 	case Float:
 		return equal[float64](input, eq.operands)
+	case Duration:
+		return equal[time.Duration](input, eq.operands)
+	case DateTime:
+		return compareTime(input, eq.operands) == 0
 	case Boolean:
 		return equal[bool](input, eq.operands)
 	case String:
@@ -121,6 +145,10 @@ func (neq *notEqualEvaluator) evaluate(input parsedInput) bool {
 		return notEqual[int64](input, neq.operands)
 	case Float:
 		return notEqual[float64](input, neq.operands)
+	case Duration:
+		return notEqual[time.Duration](input, neq.operands)
+	case DateTime:
+		return compareTime(input, neq.operands) != 0
 	case Boolean:
 		return notEqual[bool](input, neq.operands)
 	case String:
@@ -143,6 +171,149 @@ func (ce *containEvaluator) evaluate(input parsedInput) bool {
 	panic("Invalid operandType: for '" + ContainOperator + "' operator")
 }
 
+type matchEvaluator customEvaluator
+
+func (me *matchEvaluator) evaluate(input parsedInput) bool {
+	switch me.operands[0].ValueType {
+	case String:
+		return match(input, me.operands)
+	}
+
+	// no-op
+	panic("Invalid operandType: for '" + MatchOperator + "' operator")
+}
+
+type inEvaluator customEvaluator
+
+func (ie *inEvaluator) evaluate(input parsedInput) bool {
+	switch ie.operands[0].ValueType {
+	case Integer, Float, Boolean, String:
+		return in(input, ie.operands)
+	}
+
+	// no-op
+	panic("Invalid operandType: for '" + InOperator + "' operator")
+}
+
+type notInEvaluator customEvaluator
+
+func (nie *notInEvaluator) evaluate(input parsedInput) bool {
+	switch nie.operands[0].ValueType {
+	case Integer, Float, Boolean, String:
+		return !in(input, nie.operands)
+	}
+
+	// no-op
+	panic("Invalid operandType: for '" + NotInOperator + "' operator")
+}
+
+type anyOfEvaluator customEvaluator
+
+func (ae *anyOfEvaluator) evaluate(input parsedInput) bool {
+	if ae.operands[0].ValueType.isList() {
+		return anyOf(input, ae.operands)
+	}
+
+	// no-op
+	panic("Invalid operandType: for '" + AnyOfOperator + "' operator")
+}
+
+type allOfEvaluator customEvaluator
+
+func (ae *allOfEvaluator) evaluate(input parsedInput) bool {
+	if ae.operands[0].ValueType.isList() {
+		return allOf(input, ae.operands)
+	}
+
+	// no-op
+	panic("Invalid operandType: for '" + AllOfOperator + "' operator")
+}
+
+type noneOfEvaluator customEvaluator
+
+func (ne *noneOfEvaluator) evaluate(input parsedInput) bool {
+	if ne.operands[0].ValueType.isList() {
+		return !anyOf(input, ne.operands)
+	}
+
+	// no-op
+	panic("Invalid operandType: for '" + NoneOfOperator + "' operator")
+}
+
+type sizeEvaluator struct {
+	operator string
+	operands []*Operand
+}
+
+func (se *sizeEvaluator) evaluate(input parsedInput) bool {
+	if !se.operands[0].ValueType.isList() {
+		// no-op
+		panic("Invalid operandType: for '" + se.operator + "' operator")
+	}
+
+	first, second := size(input, se.operands)
+	switch se.operator {
+	case SizeEqualOperator:
+		return first == second
+	case SizeNotEqualOperator:
+		return first != second
+	case SizeGreaterOperator:
+		return first > second
+	case SizeGreaterEqualOperator:
+		return first >= second
+	case SizeLessOperator:
+		return first < second
+	case SizeLessEqualOperator:
+		return first <= second
+	}
+
+	// no-op
+	panic("operator:" + se.operator + " is invalid")
+}
+
+type isPresentEvaluator customEvaluator
+
+func (ipe *isPresentEvaluator) evaluate(input parsedInput) bool {
+	_, ok := input[ipe.operands[firstOperand].Val]
+	return ok
+}
+
+type isMissingEvaluator customEvaluator
+
+func (ime *isMissingEvaluator) evaluate(input parsedInput) bool {
+	_, ok := input[ime.operands[firstOperand].Val]
+	return !ok
+}
+
+// 'nullableEvaluator' evaluates to false if any of the nullable fields is missing in the input, otherwise evaluates
+// inner evaluator
+type nullableEvaluator struct {
+	fields         []string
+	innerEvaluator evaluator
+}
+
+func (ne *nullableEvaluator) evaluate(input parsedInput) bool {
+	for _, field := range ne.fields {
+		if _, ok := input[field]; !ok {
+			return false
+		}
+	}
+	return ne.innerEvaluator.evaluate(input)
+}
+
+type customOperatorEvaluator struct {
+	operands []*Operand
+	evalFunc func(values []any) bool
+}
+
+func (coe *customOperatorEvaluator) evaluate(input parsedInput) bool {
+	values := make([]any, len(coe.operands))
+	for i, operand := range coe.operands {
+		values[i] = operand.getValue(input)
+	}
+	return coe.evalFunc(values)
+}
+
 type evaluatorBuilderFunc func(operands []*Operand) evaluator
 
 type evaluatorFactory struct {
@@ -153,6 +324,11 @@ func (ef *evaluatorFactory) AddEvaluator(operator string, evalBuilderFunc evalua
 	ef.evaluatorBuilders[operator] = evalBuilderFunc
 }
 
+func (ef *evaluatorFactory) exist(operator string) bool {
+	_, ok := ef.evaluatorBuilders[operator]
+	return ok
+}
+
 func (ef *evaluatorFactory) build(ct *ConditionType) (evaluator, *RuleEngineError) {
 	evalBuilderFunc, ok := ef.evaluatorBuilders[ct.Operator]
 	if !ok {
@@ -163,39 +339,80 @@ func (ef *evaluatorFactory) build(ct *ConditionType) (evaluator, *RuleEngineErro
 	return evalBuilderFunc(ct.Operands), nil
 }
 
-var evalFactory = evaluatorFactory{
-	evaluatorBuilders: make(map[string]evaluatorBuilderFunc),
-}
-
-func addNewEvaluator(operator string, evalBuilderFunc evaluatorBuilderFunc) {
-	evalFactory.AddEvaluator(operator, evalBuilderFunc)
-}
+func newEvaluatorFactory() *evaluatorFactory {
+	ef := &evaluatorFactory{
+		evaluatorBuilders: make(map[string]evaluatorBuilderFunc),
+	}
 
-func init() {
-	addNewEvaluator(GreaterOperator, func(operands []*Operand) evaluator {
+	ef.AddEvaluator(GreaterOperator, func(operands []*Operand) evaluator {
 		return &greaterEvaluator{operands: operands}
 	})
-	addNewEvaluator(GreaterEqualOperator, func(operands []*Operand) evaluator {
+	ef.AddEvaluator(GreaterEqualOperator, func(operands []*Operand) evaluator {
 		return &greaterEqualEvaluator{operands: operands}
 	})
-	addNewEvaluator(LessOperator, func(operands []*Operand) evaluator {
+	ef.AddEvaluator(LessOperator, func(operands []*Operand) evaluator {
 		return &lessEvaluator{operands: operands}
 	})
-	addNewEvaluator(LessEqualOperator, func(operands []*Operand) evaluator {
+	ef.AddEvaluator(LessEqualOperator, func(operands []*Operand) evaluator {
 		return &lessEqualEvaluator{operands: operands}
 	})
-	addNewEvaluator(EqualOperator, func(operands []*Operand) evaluator {
+	ef.AddEvaluator(EqualOperator, func(operands []*Operand) evaluator {
 		return &equalEvaluator{operands: operands}
 	})
-	addNewEvaluator(NotEqualOperator, func(operands []*Operand) evaluator {
+	ef.AddEvaluator(NotEqualOperator, func(operands []*Operand) evaluator {
 		return &notEqualEvaluator{operands: operands}
 	})
-	addNewEvaluator(ContainOperator, func(operands []*Operand) evaluator {
+	ef.AddEvaluator(ContainOperator, func(operands []*Operand) evaluator {
 		return &containEvaluator{operands: operands}
 	})
+	ef.AddEvaluator(MatchOperator, func(operands []*Operand) evaluator {
+		return &matchEvaluator{operands: operands}
+	})
+	ef.AddEvaluator(InOperator, func(operands []*Operand) evaluator {
+		return &inEvaluator{operands: operands}
+	})
+	ef.AddEvaluator(NotInOperator, func(operands []*Operand) evaluator {
+		return &notInEvaluator{operands: operands}
+	})
+	ef.AddEvaluator(AnyOfOperator, func(operands []*Operand) evaluator {
+		return &anyOfEvaluator{operands: operands}
+	})
+	ef.AddEvaluator(AllOfOperator, func(operands []*Operand) evaluator {
+		return &allOfEvaluator{operands: operands}
+	})
+	ef.AddEvaluator(NoneOfOperator, func(operands []*Operand) evaluator {
+		return &noneOfEvaluator{operands: operands}
+	})
+	ef.AddEvaluator(IsPresentOperator, func(operands []*Operand) evaluator {
+		return &isPresentEvaluator{operands: operands}
+	})
+	ef.AddEvaluator(IsMissingOperator, func(operands []*Operand) evaluator {
+		return &isMissingEvaluator{operands: operands}
+	})
+	for _, sizeOperator := range []string{SizeEqualOperator, SizeNotEqualOperator, SizeGreaterOperator,
+		SizeGreaterEqualOperator, SizeLessOperator, SizeLessEqualOperator} {
+		operator := sizeOperator
+		ef.AddEvaluator(operator, func(operands []*Operand) evaluator {
+			return &sizeEvaluator{operator: operator, operands: operands}
+		})
+	}
+
+	return ef
 }
 
-func ruleEvaluatorBuild(rootCondition *Condition, conditionTypes map[string]*ConditionType, fs Fields) (evaluator, *RuleEngineError) {
+// 'ruleBuildContext' maintains engine configuration needed to build rule evaluators
+type ruleBuildContext struct {
+	conditionTypes map[string]*ConditionType
+	fields         Fields
+	fieldOptions   map[string]*FieldOption
+	evalFactory    *evaluatorFactory
+	fieldSlots     map[string]int
+
+	// evaluators of condition types, every condition type is built once and shared by rules
+	conditionEvaluators map[string]evaluator
+}
+
+func ruleEvaluatorBuild(rootCondition *Condition, buildCtx *ruleBuildContext) (evaluator, *RuleEngineError) {
 	switch c := rootCondition.Type; c {
 	case AndCondition, OrCondition, NegationCondition:
 
@@ -205,7 +422,7 @@ func ruleEvaluatorBuild(rootCondition *Condition, conditionTypes map[string]*Con
 		}
 
 		for _, subCondition := range rootCondition.SubConditions {
-			eval, err := ruleEvaluatorBuild(subCondition, conditionTypes, fs)
+			eval, err := ruleEvaluatorBuild(subCondition, buildCtx)
 			if err != nil {
 				return nil, err
 			}
@@ -215,11 +432,73 @@ func ruleEvaluatorBuild(rootCondition *Condition, conditionTypes map[string]*Con
 		return &logicalEval, nil
 	}
 
-	ct, ok := conditionTypes[rootCondition.Type]
+	if eval, ok := buildCtx.conditionEvaluators[rootCondition.Type]; ok {
+		return eval, nil
+	}
+
+	ct, ok := buildCtx.conditionTypes[rootCondition.Type]
 	if !ok {
-		return nil, newError(ErrCodeConditionTypeNotFound,
-			fmt.Sprintf("ConditionTypeName: %v", rootCondition.Type))
+		return nil, newError(ErrCodeConditionTypeNotFound).withConditionType(rootCondition.Type)
+	}
+
+	eval, err := buildConditionEvaluator(ct, buildCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if buildCtx.conditionEvaluators == nil {
+		buildCtx.conditionEvaluators = map[string]evaluator{}
+	}
+	buildCtx.conditionEvaluators[rootCondition.Type] = eval
+	return eval, nil
+}
+
+func buildConditionEvaluator(ct *ConditionType, buildCtx *ruleBuildContext) (evaluator, *RuleEngineError) {
+	eval, err := buildCtx.evalFactory.build(ct)
+	if err != nil {
+		return nil, err
+	}
+
+	// presence operators handle missing field by themselves
+	if ct.Operator == IsPresentOperator || ct.Operator == IsMissingOperator {
+		return eval, nil
 	}
 
-	return evalFactory.build(ct)
+	nullableFields := []string{}
+	for _, operand := range ct.Operands {
+		if operand.isField() && buildCtx.isNullable(operand.Val) {
+			nullableFields = append(nullableFields, operand.Val)
+		}
+	}
+
+	if len(nullableFields) != 0 {
+		return &nullableEvaluator{fields: nullableFields, innerEvaluator: eval}, nil
+	}
+	return eval, nil
+}
+
+func (buildCtx *ruleBuildContext) isNullable(fieldName string) bool {
+	option, ok := buildCtx.fieldOptions[fieldName]
+	return ok && option.Nullable
+}
+
+// indexes built condition evaluators as per 'names', index of condition type names. Condition types not indexed
+// yet are added to 'names' with next indexes in condition type name order.
+func (buildCtx *ruleBuildContext) conditionIndex(names map[string]int) map[evaluator]int {
+	newNames := []string{}
+	for name := range buildCtx.conditionEvaluators {
+		if _, ok := names[name]; !ok {
+			newNames = append(newNames, name)
+		}
+	}
+	sort.Strings(newNames)
+	for _, name := range newNames {
+		names[name] = len(names)
+	}
+
+	ret := make(map[evaluator]int, len(buildCtx.conditionEvaluators))
+	for name, eval := range buildCtx.conditionEvaluators {
+		ret[eval] = names[name]
+	}
+	return ret
 }
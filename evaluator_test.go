@@ -1,6 +1,7 @@
 package ruleenginecore
 
 import (
+	"regexp"
 	"testing"
 )
 
@@ -1712,3 +1713,475 @@ func Test_containEvaluator_evaluate(t *testing.T) {
 		})
 	}
 }
+
+func Test_matchEvaluator_evaluate(t *testing.T) {
+	type testCondition struct {
+		operands []*Operand
+	}
+	type args struct {
+		input map[string]any
+	}
+	tests := []struct {
+		name      string
+		condition testCondition
+		args      args
+		want      bool
+		wantPanic bool
+	}{
+		{
+			name: "StringType_valid",
+			condition: testCondition{
+				operands: []*Operand{
+					{
+						ValueType: String,
+						Type:      Field,
+						Val:       "sku",
+					},
+					{
+						ValueType:  String,
+						Type:       Constant,
+						Val:        "^SKU-[0-9]{4}$",
+						typedValue: regexp.MustCompile("^SKU-[0-9]{4}$"),
+					},
+				},
+			},
+			args: args{
+				map[string]any{
+					"sku": "SKU-1234",
+				},
+			},
+			want:      true,
+			wantPanic: false,
+		},
+		{
+			name: "StringType_notMatched",
+			condition: testCondition{
+				operands: []*Operand{
+					{
+						ValueType: String,
+						Type:      Field,
+						Val:       "sku",
+					},
+					{
+						ValueType:  String,
+						Type:       Constant,
+						Val:        "^SKU-[0-9]{4}$",
+						typedValue: regexp.MustCompile("^SKU-[0-9]{4}$"),
+					},
+				},
+			},
+			args: args{
+				map[string]any{
+					"sku": "SKU-12345",
+				},
+			},
+			want:      false,
+			wantPanic: false,
+		},
+		{
+			name: "StringType_matchedWithinValue",
+			condition: testCondition{
+				operands: []*Operand{
+					{
+						ValueType: String,
+						Type:      Field,
+						Val:       "sku",
+					},
+					{
+						ValueType:  String,
+						Type:       Constant,
+						Val:        "abc",
+						typedValue: regexp.MustCompile("abc"),
+					},
+				},
+			},
+			args: args{
+				map[string]any{
+					"sku": "xxabcxx",
+				},
+			},
+			want:      true,
+			wantPanic: false,
+		},
+		{
+			name: "StringType_PassedInvalidFieldType",
+			condition: testCondition{
+				operands: []*Operand{
+					{
+						ValueType: String,
+						Type:      Field,
+						Val:       "sku",
+					},
+					{
+						ValueType:  String,
+						Type:       Constant,
+						Val:        "^SKU-[0-9]{4}$",
+						typedValue: regexp.MustCompile("^SKU-[0-9]{4}$"),
+					},
+				},
+			},
+			args: args{
+				map[string]any{
+					"sku": 1, // invalid
+				},
+			},
+			want:      false,
+			wantPanic: true,
+		},
+		{
+			name: "StringType_NotCompiledPattern",
+			condition: testCondition{
+				operands: []*Operand{
+					{
+						ValueType: String,
+						Type:      Field,
+						Val:       "sku",
+					},
+					{
+						ValueType:  String,
+						Type:       Constant,
+						Val:        "^SKU-[0-9]{4}$",
+						typedValue: "^SKU-[0-9]{4}$", // invalid
+					},
+				},
+			},
+			args: args{
+				map[string]any{
+					"sku": "SKU-1234",
+				},
+			},
+			want:      false,
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if !tt.wantPanic && r == nil {
+					return
+				}
+				if tt.wantPanic && r != nil {
+					return
+				}
+
+				t.Errorf("matchEvaluator.evaluate() gotPanic:%v , want:%v wantPanic:%v", r != nil, tt.want, tt.wantPanic)
+			}()
+			me := &matchEvaluator{
+				operands: tt.condition.operands,
+			}
+			if got := me.evaluate(tt.args.input); got != tt.want {
+				t.Errorf("matchEvaluator.evaluate() got:%v, want:%v wantPanic:%v", got, tt.want, tt.wantPanic)
+			}
+		})
+	}
+}
+
+func testValueSet(values ...any) set[any] {
+	result := NewSet[any]()
+	for _, val := range values {
+		result.Add(val)
+	}
+	return result
+}
+
+func Test_inEvaluator_evaluate(t *testing.T) {
+	type testCondition struct {
+		operands []*Operand
+	}
+	type args struct {
+		input map[string]any
+	}
+	tests := []struct {
+		name      string
+		condition testCondition
+		args      args
+		want      bool
+		wantPanic bool
+	}{
+		{
+			name: "StringType_valid",
+			condition: testCondition{
+				operands: []*Operand{
+					{
+						ValueType: String,
+						Type:      Field,
+						Val:       "destination",
+					},
+					{
+						ValueType:  String,
+						Type:       Constant,
+						Val:        "Bangalore,Delhi,Mumbai",
+						typedValue: testValueSet("Bangalore", "Delhi", "Mumbai"),
+					},
+				},
+			},
+			args: args{
+				map[string]any{
+					"destination": "Delhi",
+				},
+			},
+			want:      true,
+			wantPanic: false,
+		},
+		{
+			name: "StringType_partialValue",
+			condition: testCondition{
+				operands: []*Operand{
+					{
+						ValueType: String,
+						Type:      Field,
+						Val:       "destination",
+					},
+					{
+						ValueType:  String,
+						Type:       Constant,
+						Val:        "Bangalore,Delhi,Mumbai",
+						typedValue: testValueSet("Bangalore", "Delhi", "Mumbai"),
+					},
+				},
+			},
+			args: args{
+				map[string]any{
+					"destination": "Del",
+				},
+			},
+			want:      false,
+			wantPanic: false,
+		},
+		{
+			name: "IntType_valid",
+			condition: testCondition{
+				operands: []*Operand{
+					{
+						ValueType: Integer,
+						Type:      Field,
+						Val:       "paxCount",
+					},
+					{
+						ValueType:  Integer,
+						Type:       Constant,
+						Val:        "1,2,3",
+						typedValue: testValueSet(int64(1), int64(2), int64(3)),
+					},
+				},
+			},
+			args: args{
+				map[string]any{
+					"paxCount": int64(2),
+				},
+			},
+			want:      true,
+			wantPanic: false,
+		},
+		{
+			name: "IntType_PassedInvalidConstantType",
+			condition: testCondition{
+				operands: []*Operand{
+					{
+						ValueType: Integer,
+						Type:      Field,
+						Val:       "paxCount",
+					},
+					{
+						ValueType:  Integer,
+						Type:       Constant,
+						Val:        "1,2,3",
+						typedValue: []int64{1, 2, 3}, // invalid
+					},
+				},
+			},
+			args: args{
+				map[string]any{
+					"paxCount": int64(2),
+				},
+			},
+			want:      false,
+			wantPanic: true,
+		},
+		{
+			name: "InvalidType",
+			condition: testCondition{
+				operands: []*Operand{
+					{
+						ValueType: unknownValueType,
+						Type:      Field,
+						Val:       "paxCount",
+					},
+					{
+						ValueType:  unknownValueType,
+						Type:       Constant,
+						Val:        "1,2,3",
+						typedValue: testValueSet(int64(1), int64(2), int64(3)),
+					},
+				},
+			},
+			args: args{
+				map[string]any{
+					"paxCount": int64(2),
+				},
+			},
+			want:      false,
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if !tt.wantPanic && r == nil {
+					return
+				}
+				if tt.wantPanic && r != nil {
+					return
+				}
+
+				t.Errorf("inEvaluator.evaluate() gotPanic:%v , want:%v wantPanic:%v", r != nil, tt.want, tt.wantPanic)
+			}()
+			ie := &inEvaluator{
+				operands: tt.condition.operands,
+			}
+			if got := ie.evaluate(tt.args.input); got != tt.want {
+				t.Errorf("inEvaluator.evaluate() got:%v, want:%v wantPanic:%v", got, tt.want, tt.wantPanic)
+			}
+			nie := &notInEvaluator{
+				operands: tt.condition.operands,
+			}
+			if got := nie.evaluate(tt.args.input); got != !tt.want {
+				t.Errorf("notInEvaluator.evaluate() got:%v, want:%v wantPanic:%v", got, !tt.want, tt.wantPanic)
+			}
+		})
+	}
+}
+
+func Test_listEvaluator_evaluate(t *testing.T) {
+	categories := &Operand{
+		ValueType: StringList,
+		Type:      Field,
+		Val:       "categories",
+	}
+	constantList := func(values ...any) *Operand {
+		return &Operand{
+			ValueType:  StringList,
+			Type:       Constant,
+			typedValue: testValueSet(values...),
+		}
+	}
+	fieldList := &Operand{
+		ValueType: StringList,
+		Type:      Field,
+		Val:       "wishlist",
+	}
+	paxCount := &Operand{
+		ValueType:  Integer,
+		Type:       Constant,
+		typedValue: int64(2),
+	}
+	type args struct {
+		input map[string]any
+	}
+	tests := []struct {
+		name      string
+		evaluator evaluator
+		args      args
+		want      bool
+		wantPanic bool
+	}{
+		{
+			name:      "anyOf_matched",
+			evaluator: &anyOfEvaluator{operands: []*Operand{categories, constantList("electronics")}},
+			args:      args{map[string]any{"categories": []any{"books", "electronics"}}},
+			want:      true,
+		},
+		{
+			name:      "anyOf_notMatched",
+			evaluator: &anyOfEvaluator{operands: []*Operand{categories, constantList("electronics")}},
+			args:      args{map[string]any{"categories": []any{"books"}}},
+			want:      false,
+		},
+		{
+			name:      "anyOf_fieldOperands",
+			evaluator: &anyOfEvaluator{operands: []*Operand{categories, fieldList}},
+			args:      args{map[string]any{"categories": []any{"books"}, "wishlist": []any{"toys", "books"}}},
+			want:      true,
+		},
+		{
+			name:      "allOf_matched",
+			evaluator: &allOfEvaluator{operands: []*Operand{categories, constantList("electronics", "books")}},
+			args:      args{map[string]any{"categories": []any{"books", "toys", "electronics"}}},
+			want:      true,
+		},
+		{
+			name:      "allOf_notMatched",
+			evaluator: &allOfEvaluator{operands: []*Operand{categories, constantList("electronics", "books")}},
+			args:      args{map[string]any{"categories": []any{"books", "toys"}}},
+			want:      false,
+		},
+		{
+			name:      "allOf_fieldOperands",
+			evaluator: &allOfEvaluator{operands: []*Operand{categories, fieldList}},
+			args:      args{map[string]any{"categories": []any{"books", "toys"}, "wishlist": []any{"toys", "books"}}},
+			want:      true,
+		},
+		{
+			name:      "noneOf_matched",
+			evaluator: &noneOfEvaluator{operands: []*Operand{categories, constantList("electronics")}},
+			args:      args{map[string]any{"categories": []any{"books"}}},
+			want:      true,
+		},
+		{
+			name:      "noneOf_emptyList",
+			evaluator: &noneOfEvaluator{operands: []*Operand{categories, constantList("electronics")}},
+			args:      args{map[string]any{"categories": []any{}}},
+			want:      true,
+		},
+		{
+			name:      "anyOf_PassedInvalidFieldType",
+			evaluator: &anyOfEvaluator{operands: []*Operand{categories, constantList("electronics")}},
+			args:      args{map[string]any{"categories": "electronics"}},
+			wantPanic: true,
+		},
+		{
+			name:      "sizeGreater_matched",
+			evaluator: &sizeEvaluator{operator: SizeGreaterOperator, operands: []*Operand{categories, paxCount}},
+			args:      args{map[string]any{"categories": []any{"books", "toys", "electronics"}}},
+			want:      true,
+		},
+		{
+			name:      "sizeEqual_matched",
+			evaluator: &sizeEvaluator{operator: SizeEqualOperator, operands: []*Operand{categories, paxCount}},
+			args:      args{map[string]any{"categories": []any{"books", "toys"}}},
+			want:      true,
+		},
+		{
+			name:      "sizeLess_notMatched",
+			evaluator: &sizeEvaluator{operator: SizeLessOperator, operands: []*Operand{categories, paxCount}},
+			args:      args{map[string]any{"categories": []any{"books", "toys"}}},
+			want:      false,
+		},
+		{
+			name:      "size_InvalidOperator",
+			evaluator: &sizeEvaluator{operator: "size", operands: []*Operand{categories, paxCount}},
+			args:      args{map[string]any{"categories": []any{"books", "toys"}}},
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if !tt.wantPanic && r == nil {
+					return
+				}
+				if tt.wantPanic && r != nil {
+					return
+				}
+
+				t.Errorf("evaluate() gotPanic:%v , want:%v wantPanic:%v", r != nil, tt.want, tt.wantPanic)
+			}()
+			if got := tt.evaluator.evaluate(tt.args.input); got != tt.want {
+				t.Errorf("evaluate() got:%v, want:%v wantPanic:%v", got, tt.want, tt.wantPanic)
+			}
+		})
+	}
+}
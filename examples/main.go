@@ -40,7 +40,7 @@ func main() {
 						equalCondition with bool operand type
 
 				3. (flightDestination are Bangalore,Delhi,Mumbai,Chennai)
-						containCondition with string operand type
+						inCondition with string operand type
 
 			Step.3: define rule combining custom conditions
 				rule condition combination supports 'and','or','not' operations
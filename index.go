@@ -0,0 +1,521 @@
+package ruleenginecore
+
+import (
+	"math/bits"
+	"sort"
+	"sync"
+)
+
+// 'ruleIndex' maintains rules by field values expected by their equality ('==') or 'in' conditions, which are
+// either the root condition or a sub condition of root 'and' condition. Rule can only match if input field has one of
+// the expected values, hence evaluation considers indexed rules having matching field value and all rules which are
+// not indexed.
+//
+// Rules without such conditions are indexed by range of Integer or Float field expected by their '>', '>=', '<', '<='
+// conditions having constant operand, ranges are maintained with interval tree per field.
+//
+// Rules are indexed by declaration order, which is kept as is across updates unlike position, hence an updated engine
+// patches index entries of updated rules only.
+type ruleIndex struct {
+	fields []*fieldIndex
+
+	integerRanges []*rangeIndex[int64]
+	floatRanges   []*rangeIndex[float64]
+
+	// declaration orders of rules which are not indexed
+	unindexed []int
+
+	// positions of rules by declaration order
+	positions []int
+
+	// bitsets of candidate rules, reused across evaluations
+	candidateSets *sync.Pool
+}
+
+type rangeIndex[T int64 | float64] struct {
+	slot int
+	tree *intervalTree[T]
+}
+
+type fieldIndex struct {
+	slot int
+
+	// field value and declaration orders of rules expecting the value
+	rules map[any][]int
+}
+
+// 'indexEntry' is the way a rule is indexed, either by field values, by range of Integer or Float field or not
+// indexed at all having negative slot
+type indexEntry struct {
+	order int
+	slot  int
+
+	values       []any
+	integerRange *interval[int64]
+	floatRange   *interval[float64]
+}
+
+func newIndexEntry(ru *rule, fieldSlots map[string]int) *indexEntry {
+	entry := &indexEntry{order: ru.order, slot: -1}
+	if field, values, ok := indexableCondition(ru.rootEvaluator); ok {
+		entry.slot, entry.values = fieldSlots[field], values
+		return entry
+	}
+
+	field, valueType, bounds, ok := indexableRange(ru.rootEvaluator)
+	if !ok {
+		return entry
+	}
+	entry.slot = fieldSlots[field]
+	if valueType == Integer {
+		entry.integerRange = newInterval[int64](bounds, ru.order)
+	} else {
+		entry.floatRange = newInterval[float64](bounds, ru.order)
+	}
+	return entry
+}
+
+func (entry *indexEntry) isFieldValues() bool {
+	return entry.slot >= 0 && entry.integerRange == nil && entry.floatRange == nil
+}
+
+// indexes rules, where rules are ordered by priority
+func newRuleIndex(rules []*rule, fieldSlots map[string]int) *ruleIndex {
+	empty := &ruleIndex{unindexed: []int{}}
+	return empty.update(rules, fieldSlots, nil, rules)
+}
+
+// returns index of 'rules' derived from the index having 'removed' rules unindexed and 'added' rules indexed, where
+// 'rules' are ordered by priority. Only index entries of fields expected by removed or added rules are patched, other
+// entries are shared with the index.
+func (ri *ruleIndex) update(rules []*rule, fieldSlots map[string]int, removed, added []*rule) *ruleIndex {
+	ret := &ruleIndex{positions: rulePositions(rules), candidateSets: &sync.Pool{}}
+	ret.candidateSets.New = func() any {
+		set := newBitset(len(rules))
+		return &set
+	}
+
+	removedOrders := map[int]bool{}
+	removedEntries := make([]*indexEntry, 0, len(removed))
+	for _, ru := range removed {
+		removedOrders[ru.order] = true
+		removedEntries = append(removedEntries, newIndexEntry(ru, fieldSlots))
+	}
+	addedEntries := make([]*indexEntry, 0, len(added))
+	for _, ru := range added {
+		addedEntries = append(addedEntries, newIndexEntry(ru, fieldSlots))
+	}
+
+	ret.unindexed = patchUnindexed(ri.unindexed, removedOrders, removedEntries, addedEntries)
+	ret.fields = patchFieldIndexes(ri.fields, removedOrders, removedEntries, addedEntries)
+
+	integerSlots, floatSlots := map[int]bool{}, map[int]bool{}
+	integerIntervals, floatIntervals := map[int][]*interval[int64]{}, map[int][]*interval[float64]{}
+	for _, entry := range removedEntries {
+		if entry.integerRange != nil {
+			integerSlots[entry.slot] = true
+		} else if entry.floatRange != nil {
+			floatSlots[entry.slot] = true
+		}
+	}
+	for _, entry := range addedEntries {
+		if entry.integerRange != nil {
+			integerIntervals[entry.slot] = append(integerIntervals[entry.slot], entry.integerRange)
+		} else if entry.floatRange != nil {
+			floatIntervals[entry.slot] = append(floatIntervals[entry.slot], entry.floatRange)
+		}
+	}
+	ret.integerRanges = patchRangeIndexes(ri.integerRanges, removedOrders, integerSlots, integerIntervals)
+	ret.floatRanges = patchRangeIndexes(ri.floatRanges, removedOrders, floatSlots, floatIntervals)
+	return ret
+}
+
+// returns positions of rules by declaration order
+func rulePositions(rules []*rule) []int {
+	size := 0
+	for _, ru := range rules {
+		if ru.order >= size {
+			size = ru.order + 1
+		}
+	}
+	ret := make([]int, size)
+	for position, ru := range rules {
+		ret[ru.order] = position
+	}
+	return ret
+}
+
+// returns unindexed rules having removed rules dropped and added unindexed rules appended, unindexed rules are kept
+// as is if neither removed nor added rule is unindexed
+func patchUnindexed(unindexed []int, removedOrders map[int]bool, removed, added []*indexEntry) []int {
+	if !hasUnindexed(removed) && !hasUnindexed(added) {
+		return unindexed
+	}
+
+	ret := make([]int, 0, len(unindexed)+len(added))
+	for _, order := range unindexed {
+		if !removedOrders[order] {
+			ret = append(ret, order)
+		}
+	}
+	for _, entry := range added {
+		if entry.slot < 0 {
+			ret = append(ret, entry.order)
+		}
+	}
+	return ret
+}
+
+func hasUnindexed(entries []*indexEntry) bool {
+	for _, entry := range entries {
+		if entry.slot < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// returns field indexes having removed rules dropped and added rules indexed, field indexes of fields expected by
+// neither removed nor added rule are shared
+func patchFieldIndexes(fields []*fieldIndex, removedOrders map[int]bool, removed, added []*indexEntry) []*fieldIndex {
+	// changed values by slot, having declaration orders of added rules expecting the value
+	changes := map[int]map[any][]int{}
+	valueChanges := func(slot int) map[any][]int {
+		if _, ok := changes[slot]; !ok {
+			changes[slot] = map[any][]int{}
+		}
+		return changes[slot]
+	}
+	for _, entry := range removed {
+		if entry.isFieldValues() {
+			for _, val := range entry.values {
+				orders := valueChanges(entry.slot)
+				if _, ok := orders[val]; !ok {
+					orders[val] = nil
+				}
+			}
+		}
+	}
+	for _, entry := range added {
+		if entry.isFieldValues() {
+			for _, val := range entry.values {
+				orders := valueChanges(entry.slot)
+				orders[val] = append(orders[val], entry.order)
+			}
+		}
+	}
+
+	ret := make([]*fieldIndex, 0, len(fields)+len(changes))
+	for _, fi := range fields {
+		if valueChanges, ok := changes[fi.slot]; ok {
+			delete(changes, fi.slot)
+			fi = fi.patch(removedOrders, valueChanges)
+		}
+		if len(fi.rules) != 0 {
+			ret = append(ret, fi)
+		}
+	}
+
+	slots := make([]int, 0, len(changes))
+	for slot := range changes {
+		slots = append(slots, slot)
+	}
+	sort.Ints(slots)
+	for _, slot := range slots {
+		fi := &fieldIndex{slot: slot, rules: map[any][]int{}}
+		ret = append(ret, fi.patch(removedOrders, changes[slot]))
+	}
+	return ret
+}
+
+// returns copy of the field index, where rules of changed values are having removed rules dropped and added rules
+// appended
+func (fi *fieldIndex) patch(removedOrders map[int]bool, changes map[any][]int) *fieldIndex {
+	ret := &fieldIndex{slot: fi.slot, rules: make(map[any][]int, len(fi.rules)+len(changes))}
+	for val, orders := range fi.rules {
+		ret.rules[val] = orders
+	}
+
+	for val, addedOrders := range changes {
+		orders := make([]int, 0, len(fi.rules[val])+len(addedOrders))
+		for _, order := range fi.rules[val] {
+			if !removedOrders[order] {
+				orders = append(orders, order)
+			}
+		}
+		orders = append(orders, addedOrders...)
+
+		if len(orders) == 0 {
+			delete(ret.rules, val)
+		} else {
+			ret.rules[val] = orders
+		}
+	}
+	return ret
+}
+
+// returns range indexes having intervals of removed rules dropped and added intervals indexed, trees of fields having
+// neither removed nor added interval are shared
+func patchRangeIndexes[T int64 | float64](ranges []*rangeIndex[T], removedOrders map[int]bool, removedSlots map[int]bool,
+	added map[int][]*interval[T]) []*rangeIndex[T] {
+	intervals := map[int][]*interval[T]{}
+	ret := make([]*rangeIndex[T], 0, len(ranges)+len(added))
+	for _, r := range ranges {
+		if _, ok := added[r.slot]; !ok && !removedSlots[r.slot] {
+			ret = append(ret, r)
+			continue
+		}
+
+		intervals[r.slot] = []*interval[T]{}
+		for _, i := range r.tree.intervals {
+			if !removedOrders[i.order] {
+				intervals[r.slot] = append(intervals[r.slot], i)
+			}
+		}
+	}
+	for slot, slotIntervals := range added {
+		intervals[slot] = append(intervals[slot], slotIntervals...)
+	}
+
+	ret = append(ret, newRangeIndexes(intervals)...)
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].slot < ret[j].slot
+	})
+	return ret
+}
+
+func newRangeIndexes[T int64 | float64](intervals map[int][]*interval[T]) []*rangeIndex[T] {
+	ret := make([]*rangeIndex[T], 0, len(intervals))
+	for slot, slotIntervals := range intervals {
+		if len(slotIntervals) != 0 {
+			ret = append(ret, &rangeIndex[T]{slot: slot, tree: newIntervalTree(slotIntervals)})
+		}
+	}
+	return ret
+}
+
+// returns candidate rules for the input as bitset of rule positions, bitset is expected to be released with
+// 'releaseCandidates' once candidates are evaluated
+func (ri *ruleIndex) candidates(in *slotInput) *bitset {
+	ret := ri.candidateSets.Get().(*bitset)
+	for _, order := range ri.unindexed {
+		ret.add(ri.positions[order])
+	}
+	for _, fi := range ri.fields {
+		if val := in.slots[fi.slot]; val != nil {
+			for _, order := range fi.rules[val] {
+				ret.add(ri.positions[order])
+			}
+		}
+	}
+	for _, r := range ri.integerRanges {
+		if val, ok := in.slots[r.slot].(int64); ok {
+			r.tree.stab(val, ri.positions, ret)
+		}
+	}
+	for _, r := range ri.floatRanges {
+		if val, ok := in.slots[r.slot].(float64); ok {
+			r.tree.stab(val, ri.positions, ret)
+		}
+	}
+	return ret
+}
+
+func (ri *ruleIndex) releaseCandidates(candidates *bitset) {
+	candidates.clear()
+	ri.candidateSets.Put(candidates)
+}
+
+// 'bitset' of rule positions, having positions in ascending order without sorting
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) add(position int) {
+	b[position/64] |= 1 << (uint(position) % 64)
+}
+
+func (b bitset) clear() {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// returns least position in the bitset greater than or equal to 'from', -1 if there is none
+func (b bitset) next(from int) int {
+	if from < 0 {
+		from = 0
+	}
+	for i := from / 64; i < len(b); i++ {
+		word := b[i]
+		if i == from/64 {
+			word &= ^uint64(0) << (uint(from) % 64)
+		}
+		if word != 0 {
+			return i*64 + bits.TrailingZeros64(word)
+		}
+	}
+	return -1
+}
+
+// returns greatest position in the bitset less than or equal to 'from', -1 if there is none
+func (b bitset) prev(from int) int {
+	if from >= len(b)*64 {
+		from = len(b)*64 - 1
+	}
+	for i := from / 64; i >= 0 && from >= 0; i-- {
+		word := b[i]
+		if i == from/64 {
+			word &= ^uint64(0) >> (63 - uint(from)%64)
+		}
+		if word != 0 {
+			return i*64 + 63 - bits.LeadingZeros64(word)
+		}
+	}
+	return -1
+}
+
+// finds a condition of the rule, which is required to be true for the rule to match and expects field to have one
+// of the constant values. Condition having least values is considered among multiple such conditions.
+func indexableCondition(rootEvaluator evaluator) (string, []any, bool) {
+	conditions := []evaluator{rootEvaluator}
+	if le, ok := rootEvaluator.(*logicalEvaluator); ok {
+		if le.operator != AndCondition {
+			return "", nil, false
+		}
+		conditions = le.innerEvaluators
+	}
+
+	field, values, found := "", []any(nil), false
+	for _, condition := range conditions {
+		f, v, ok := indexableValues(condition)
+		if ok && (!found || len(v) < len(values)) {
+			field, values, found = f, v, true
+		}
+	}
+	return field, values, found
+}
+
+// 'rangeBound' is a bound of range expected by comparison condition, having constant value of Integer or Float field
+type rangeBound struct {
+	cmp   comparison
+	value any
+}
+
+// finds range of a field, which is required to be satisfied for the rule to match, considering comparison conditions
+// of the rule having field and constant operands. Field having both lower and upper bound is preferred.
+func indexableRange(rootEvaluator evaluator) (string, ValueType, []rangeBound, bool) {
+	conditions := []evaluator{rootEvaluator}
+	if le, ok := rootEvaluator.(*logicalEvaluator); ok {
+		if le.operator != AndCondition {
+			return "", unknownValueType, nil, false
+		}
+		conditions = le.innerEvaluators
+	}
+
+	fields := []string{}
+	fieldTypes := map[string]ValueType{}
+	fieldBounds := map[string][]rangeBound{}
+	for _, condition := range conditions {
+		// missing nullable field does not belong to any range, which is same as nullable evaluator
+		if ne, ok := condition.(*nullableEvaluator); ok {
+			condition = ne.innerEvaluator
+		}
+
+		cmp, operands, ok := comparisonOf(condition)
+		if !ok || cmp == cmpEqual || cmp == cmpNotEqual {
+			continue
+		}
+
+		field, constant := operands[firstOperand], operands[secondOperand]
+		if !field.isField() {
+			field, constant, cmp = constant, field, cmp.swap()
+		}
+		if !field.isField() || constant.Type != Constant || (field.ValueType != Integer && field.ValueType != Float) {
+			continue
+		}
+
+		if _, ok := fieldBounds[field.Val]; !ok {
+			fields = append(fields, field.Val)
+			fieldTypes[field.Val] = field.ValueType
+		}
+		fieldBounds[field.Val] = append(fieldBounds[field.Val], rangeBound{cmp: cmp, value: constant.typedValue})
+	}
+
+	if len(fields) == 0 {
+		return "", unknownValueType, nil, false
+	}
+
+	chosen := fields[0]
+	for _, field := range fields {
+		hasLower, hasUpper := false, false
+		for _, b := range fieldBounds[field] {
+			hasLower = hasLower || b.cmp == cmpGreater || b.cmp == cmpGreaterEqual
+			hasUpper = hasUpper || b.cmp == cmpLess || b.cmp == cmpLessEqual
+		}
+		if hasLower && hasUpper {
+			chosen = field
+			break
+		}
+	}
+	return chosen, fieldTypes[chosen], fieldBounds[chosen], true
+}
+
+// returns interval satisfying all bounds, expected by rule having declaration 'order'
+func newInterval[T int64 | float64](bounds []rangeBound, order int) *interval[T] {
+	i := &interval[T]{
+		lower: bound[T]{unbounded: true},
+		upper: bound[T]{unbounded: true},
+		order: order,
+	}
+	for _, b := range bounds {
+		value := b.value.(T)
+		switch b.cmp {
+		case cmpGreater, cmpGreaterEqual:
+			i.restrictLower(bound[T]{value: value, inclusive: b.cmp == cmpGreaterEqual})
+		case cmpLess, cmpLessEqual:
+			i.restrictUpper(bound[T]{value: value, inclusive: b.cmp == cmpLessEqual})
+		}
+	}
+	return i
+}
+
+// returns field and constant values expected by equality or 'in' condition
+func indexableValues(eval evaluator) (string, []any, bool) {
+	// missing nullable field does not match any value, which is same as nullable evaluator
+	if ne, ok := eval.(*nullableEvaluator); ok {
+		eval = ne.innerEvaluator
+	}
+
+	var operands []*Operand
+	switch e := eval.(type) {
+	case *equalEvaluator:
+		operands = e.operands
+	case *inEvaluator:
+		operands = e.operands
+	default:
+		return "", nil, false
+	}
+
+	field, constant := operands[firstOperand], operands[secondOperand]
+	if !field.isField() {
+		field, constant = constant, field
+	}
+	if !field.isField() || constant.Type != Constant {
+		return "", nil, false
+	}
+
+	// DateTime values are compared by instant, which differs from value equality
+	switch field.ValueType {
+	case Boolean, Integer, Float, String, Duration:
+	default:
+		return "", nil, false
+	}
+
+	if valueSet, ok := constant.typedValue.(set[any]); ok {
+		return field.Val, valueSet.Elements(), true
+	}
+	return field.Val, []any{constant.typedValue}, true
+}
@@ -0,0 +1,353 @@
+package ruleenginecore
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func Test_indexableCondition(t *testing.T) {
+	merchantIsM1 := &equalEvaluator{operands: []*Operand{
+		{Type: Field, ValueType: String, Val: "merchant"},
+		{Type: Constant, ValueType: String, Val: "m1", typedValue: "m1"},
+	}}
+	categoryIn := &inEvaluator{operands: []*Operand{
+		{Type: Field, ValueType: String, Val: "category"},
+		{Type: Constant, ValueType: String, Val: "c1,c2", typedValue: testValueSet("c1", "c2")},
+	}}
+	amountMoreThan10 := &greaterEvaluator{operands: []*Operand{
+		{Type: Field, ValueType: Integer, Val: "amount"},
+		{Type: Constant, ValueType: Integer, Val: "10", typedValue: int64(10)},
+	}}
+	createdAtEqual := &equalEvaluator{operands: []*Operand{
+		{Type: Field, ValueType: DateTime, Val: "createdAt"},
+		{Type: Constant, ValueType: DateTime, Val: "2024-01-01T00:00:00Z"},
+	}}
+
+	tests := []struct {
+		name       string
+		eval       evaluator
+		wantField  string
+		wantValues []any
+		wantOk     bool
+	}{
+		{
+			name:       "rootEquality",
+			eval:       merchantIsM1,
+			wantField:  "merchant",
+			wantValues: []any{"m1"},
+			wantOk:     true,
+		},
+		{
+			name: "andPrefersLeastValues",
+			eval: &logicalEvaluator{operator: AndCondition, innerEvaluators: []evaluator{
+				amountMoreThan10, categoryIn, merchantIsM1,
+			}},
+			wantField:  "merchant",
+			wantValues: []any{"m1"},
+			wantOk:     true,
+		},
+		{
+			name:       "nullableIn",
+			eval:       &nullableEvaluator{fields: []string{"category"}, innerEvaluator: categoryIn},
+			wantField:  "category",
+			wantValues: []any{"c1", "c2"},
+			wantOk:     true,
+		},
+		{
+			name: "invalid_OrCondition",
+			eval: &logicalEvaluator{operator: OrCondition, innerEvaluators: []evaluator{
+				merchantIsM1, categoryIn,
+			}},
+			wantOk: false,
+		},
+		{
+			name: "invalid_NegatedEquality",
+			eval: &logicalEvaluator{operator: AndCondition, innerEvaluators: []evaluator{
+				&logicalEvaluator{operator: NegationCondition, innerEvaluators: []evaluator{merchantIsM1}},
+			}},
+			wantOk: false,
+		},
+		{
+			name:   "invalid_RangeCondition",
+			eval:   amountMoreThan10,
+			wantOk: false,
+		},
+		{
+			name:   "invalid_DateTimeEquality",
+			eval:   createdAtEqual,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotField, gotValues, gotOk := indexableCondition(tt.eval)
+			if gotOk != tt.wantOk {
+				t.Fatalf("indexableCondition() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+			if !gotOk {
+				return
+			}
+			sort.Slice(gotValues, func(i, j int) bool { return gotValues[i].(string) < gotValues[j].(string) })
+			if gotField != tt.wantField || !reflect.DeepEqual(gotValues, tt.wantValues) {
+				t.Errorf("indexableCondition() = %v %v, want %v %v", gotField, gotValues, tt.wantField, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestRuleEngine_RuleIndex(t *testing.T) {
+	config := &RuleEngineConfig{
+		Fields: Fields{
+			"merchant": String,
+			"category": String,
+			"amount":   Integer,
+			"coupon":   String,
+		},
+		FieldOptions: map[string]*FieldOption{
+			"coupon": {Nullable: true},
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"amountMoreThan500": {Operator: GreaterOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Integer, Val: "amount"},
+				{Type: Constant, ValueType: Integer, Val: "500"},
+			}},
+			"couponIsFestive": {Operator: EqualOperator, Operands: []*Operand{
+				{Type: Constant, ValueType: String, Val: "FESTIVE"},
+				{Type: Field, ValueType: String, Val: "coupon"},
+			}},
+		},
+		Rules: map[string]*RuleConfig{},
+	}
+	for i := 0; i < 5; i++ {
+		config.ConditionTypes["merchantIs"+strconv.Itoa(i)] = &ConditionType{Operator: EqualOperator, Operands: []*Operand{
+			{Type: Field, ValueType: String, Val: "merchant"},
+			{Type: Constant, ValueType: String, Val: "m" + strconv.Itoa(i)},
+		}}
+		config.ConditionTypes["categoryIn"+strconv.Itoa(i)] = &ConditionType{Operator: InOperator, Operands: []*Operand{
+			{Type: Field, ValueType: String, Val: "category"},
+			{Type: Constant, ValueType: String, Val: "c" + strconv.Itoa(i) + ",c" + strconv.Itoa(i+1)},
+		}}
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		merchant := &Condition{Type: "merchantIs" + strconv.Itoa(random.Intn(5))}
+		category := &Condition{Type: "categoryIn" + strconv.Itoa(random.Intn(5))}
+		amount := &Condition{Type: "amountMoreThan500"}
+		coupon := &Condition{Type: "couponIsFestive"}
+
+		var root *Condition
+		switch i % 5 {
+		case 0:
+			root = merchant
+		case 1:
+			root = &Condition{Type: AndCondition, SubConditions: []*Condition{amount, merchant, category}}
+		case 2:
+			root = &Condition{Type: AndCondition, SubConditions: []*Condition{category, coupon}}
+		case 3:
+			root = &Condition{Type: OrCondition, SubConditions: []*Condition{merchant, amount}}
+		case 4:
+			root = &Condition{Type: AndCondition, SubConditions: []*Condition{
+				amount, {Type: NegationCondition, SubConditions: []*Condition{merchant}},
+			}}
+		}
+		config.Rules["rule"+strconv.Itoa(i)] = &RuleConfig{Priority: i, RootCondition: root}
+	}
+
+	linear, err := New(config)
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+	indexed, err := New(config, WithRuleIndex())
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+	// rules having 'or' root condition are not indexed
+	if got := len(indexed.(*ruleEngine).index.unindexed); got != 20 {
+		t.Errorf("newRuleIndex() unindexed rules = %v, want 20", got)
+	}
+
+	options := map[string]*evaluateOption{
+		"complete":   EvaluateOptions().Complete(),
+		"ascending":  EvaluateOptions().AscendingPriorityBased(3),
+		"descending": EvaluateOptions().DescendingPriorityBased(3),
+	}
+	for i := 0; i < 50; i++ {
+		input := Input{
+			"merchant": "m" + strconv.Itoa(random.Intn(6)),
+			"category": "c" + strconv.Itoa(random.Intn(7)),
+			"amount":   strconv.Itoa(random.Intn(1000)),
+		}
+		if random.Intn(2) == 0 {
+			input["coupon"] = "FESTIVE"
+		}
+
+		for name, op := range options {
+			want, wantErr := linear.Evaluate(context.TODO(), input, op)
+			got, gotErr := indexed.Evaluate(context.TODO(), input, op)
+			if wantErr != nil || gotErr != nil {
+				t.Fatalf("Evaluate() gotErr %v, %v", wantErr, gotErr)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Evaluate() %v input %v got %v, want %v", name, input, got, want)
+			}
+		}
+	}
+}
+
+func TestRuleEngine_RangeIndex(t *testing.T) {
+	config := &RuleEngineConfig{
+		Fields:         Fields{"amount": Integer, "weight": Float},
+		ConditionTypes: map[string]*ConditionType{},
+		Rules:          map[string]*RuleConfig{},
+	}
+	operators := []string{GreaterOperator, GreaterEqualOperator, LessOperator, LessEqualOperator}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		subConditions := []*Condition{}
+		count := 2 + random.Intn(2)
+		for j := 0; j < count; j++ {
+			name := "cond" + strconv.Itoa(i) + "_" + strconv.Itoa(j)
+			operator := operators[random.Intn(len(operators))]
+			operands := []*Operand{
+				{Type: Field, ValueType: Integer, Val: "amount"},
+				{Type: Constant, ValueType: Integer, Val: strconv.Itoa(random.Intn(100))},
+			}
+			if random.Intn(3) == 0 {
+				operands = []*Operand{
+					{Type: Constant, ValueType: Float, Val: strconv.Itoa(random.Intn(100)) + ".5"},
+					{Type: Field, ValueType: Float, Val: "weight"},
+				}
+			}
+			config.ConditionTypes[name] = &ConditionType{Operator: operator, Operands: operands}
+			subConditions = append(subConditions, &Condition{Type: name})
+		}
+		config.Rules["rule"+strconv.Itoa(i)] = &RuleConfig{
+			Priority:      i,
+			RootCondition: &Condition{Type: AndCondition, SubConditions: subConditions},
+		}
+	}
+
+	linear, err := New(config)
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+	indexed, err := New(config, WithRuleIndex())
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+	if got := len(indexed.(*ruleEngine).index.unindexed); got != 0 {
+		t.Errorf("newRuleIndex() unindexed rules = %v, want 0", got)
+	}
+
+	for i := 0; i < 200; i++ {
+		input := Input{
+			"amount": strconv.Itoa(random.Intn(110) - 5),
+			"weight": strconv.FormatFloat(float64(random.Intn(220)-10)/2, 'f', -1, 64),
+		}
+		want, wantErr := linear.Evaluate(context.TODO(), input, EvaluateOptions().Complete())
+		got, gotErr := indexed.Evaluate(context.TODO(), input, EvaluateOptions().Complete())
+		if wantErr != nil || gotErr != nil {
+			t.Fatalf("Evaluate() gotErr %v, %v", wantErr, gotErr)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Evaluate() input %v got %v, want %v", input, got, want)
+		}
+	}
+}
+
+func Benchmark_RuleEngine_RangeIndex(b *testing.B) {
+	config := &RuleEngineConfig{
+		Fields:         Fields{"amount": Integer},
+		ConditionTypes: map[string]*ConditionType{},
+		Rules:          map[string]*RuleConfig{},
+	}
+	for i := 0; i < 1000; i++ {
+		lo, hi := strconv.Itoa(i*1000), strconv.Itoa(i*1000+1000)
+		config.ConditionTypes["amountAtLeast"+lo] = &ConditionType{Operator: GreaterEqualOperator, Operands: []*Operand{
+			{Type: Field, ValueType: Integer, Val: "amount"},
+			{Type: Constant, ValueType: Integer, Val: lo},
+		}}
+		config.ConditionTypes["amountAtMost"+hi] = &ConditionType{Operator: LessEqualOperator, Operands: []*Operand{
+			{Type: Field, ValueType: Integer, Val: "amount"},
+			{Type: Constant, ValueType: Integer, Val: hi},
+		}}
+		config.Rules["rule"+strconv.Itoa(i)] = &RuleConfig{Priority: i, RootCondition: &Condition{
+			Type:          AndCondition,
+			SubConditions: []*Condition{{Type: "amountAtLeast" + lo}, {Type: "amountAtMost" + hi}},
+		}}
+	}
+	input := TypedInput{"amount": int64(500500)}
+
+	for _, bench := range []struct {
+		name string
+		opts []EngineOption
+	}{
+		{name: "linear"},
+		{name: "indexed", opts: []EngineOption{WithRuleIndex()}},
+	} {
+		engine, err := New(config, bench.opts...)
+		if err != nil {
+			b.Fatalf("New() gotErr %v", err)
+		}
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = engine.EvaluateTyped(context.TODO(), input, EvaluateOptions().Complete())
+			}
+		})
+	}
+}
+
+func Test_bitset(t *testing.T) {
+	set := newBitset(200)
+	positions := []int{0, 3, 63, 64, 130, 199}
+	for _, position := range positions {
+		set.add(position)
+	}
+
+	gotAscending := []int{}
+	for position := set.next(0); position >= 0; position = set.next(position + 1) {
+		gotAscending = append(gotAscending, position)
+	}
+	if !reflect.DeepEqual(gotAscending, positions) {
+		t.Errorf("bitset.next() got %v, want %v", gotAscending, positions)
+	}
+
+	gotDescending := []int{}
+	for position := set.prev(199); position >= 0; position = set.prev(position - 1) {
+		gotDescending = append(gotDescending, position)
+	}
+	wantDescending := []int{199, 130, 64, 63, 3, 0}
+	if !reflect.DeepEqual(gotDescending, wantDescending) {
+		t.Errorf("bitset.prev() got %v, want %v", gotDescending, wantDescending)
+	}
+
+	set.clear()
+	if got := set.next(0); got != -1 {
+		t.Errorf("bitset.next() of cleared bitset = %v, want -1", got)
+	}
+}
+
+func Test_ruleIndex_candidates_Allocations(t *testing.T) {
+	engine, err := New(testUpdateConfig(), WithRuleIndex())
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+	re := engine.(*ruleEngine)
+	input, parseErr := re.validateAndParseInput(Input{"amount": "150", "city": "Delhi"})
+	if parseErr != nil {
+		t.Fatalf("validateAndParseInput() gotErr %v", parseErr)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		re.index.releaseCandidates(re.index.candidates(input))
+	})
+	if allocs != 0 {
+		t.Errorf("ruleIndex.candidates() allocations = %v, want 0", allocs)
+	}
+}
@@ -0,0 +1,115 @@
+package ruleenginecore
+
+import "sort"
+
+// 'bound' is a bound of an interval, unbounded bound considers all values
+type bound[T int64 | float64] struct {
+	value     T
+	inclusive bool
+	unbounded bool
+}
+
+// 'interval' maintains range of values expected by a rule having declaration 'order'
+type interval[T int64 | float64] struct {
+	lower bound[T]
+	upper bound[T]
+	order int
+}
+
+func (i *interval[T]) aboveLower(val T) bool {
+	return i.lower.unbounded || val > i.lower.value || (i.lower.inclusive && val == i.lower.value)
+}
+
+func (i *interval[T]) belowUpper(val T) bool {
+	return i.upper.unbounded || val < i.upper.value || (i.upper.inclusive && val == i.upper.value)
+}
+
+// narrows interval by given lower bound
+func (i *interval[T]) restrictLower(b bound[T]) {
+	if i.lower.unbounded || b.value > i.lower.value || (b.value == i.lower.value && !b.inclusive) {
+		i.lower = b
+	}
+}
+
+// narrows interval by given upper bound
+func (i *interval[T]) restrictUpper(b bound[T]) {
+	if i.upper.unbounded || b.value < i.upper.value || (b.value == i.upper.value && !b.inclusive) {
+		i.upper = b
+	}
+}
+
+// 'intervalTree' is a static interval tree, intervals are sorted by lower bound and maintained as implicit balanced
+// binary tree, where every node maintains highest upper bound of its subtree. Finding intervals containing a value
+// takes O(log n + k) for k matching intervals.
+type intervalTree[T int64 | float64] struct {
+	intervals []*interval[T]
+
+	// highest upper bound of subtree rooted at index
+	maxUpper []bound[T]
+}
+
+func newIntervalTree[T int64 | float64](intervals []*interval[T]) *intervalTree[T] {
+	sort.SliceStable(intervals, func(i, j int) bool {
+		// inclusive lower bound is considered lower than exclusive one having the same value
+		a, b := intervals[i].lower, intervals[j].lower
+		if a.unbounded || b.unbounded {
+			return a.unbounded && !b.unbounded
+		}
+		return a.value < b.value || (a.value == b.value && a.inclusive && !b.inclusive)
+	})
+
+	t := &intervalTree[T]{intervals: intervals, maxUpper: make([]bound[T], len(intervals))}
+	t.build(0, len(intervals)-1)
+	return t
+}
+
+func (t *intervalTree[T]) build(low, high int) (bound[T], bool) {
+	if low > high {
+		return bound[T]{}, false
+	}
+	mid := (low + high) / 2
+	maxUpper := t.intervals[mid].upper
+	for _, child := range [][2]int{{low, mid - 1}, {mid + 1, high}} {
+		if b, ok := t.build(child[0], child[1]); ok && higherBound(b, maxUpper) {
+			maxUpper = b
+		}
+	}
+	t.maxUpper[mid] = maxUpper
+	return maxUpper, true
+}
+
+func higherBound[T int64 | float64](a, b bound[T]) bool {
+	if a.unbounded || b.unbounded {
+		return a.unbounded && !b.unbounded
+	}
+	return a.value > b.value || (a.value == b.value && a.inclusive && !b.inclusive)
+}
+
+// adds positions of intervals containing the value to 'ret', 'positions' are positions of rules by declaration order
+func (t *intervalTree[T]) stab(val T, positions []int, ret *bitset) {
+	t.stabRange(0, len(t.intervals)-1, val, positions, ret)
+}
+
+func (t *intervalTree[T]) stabRange(low, high int, val T, positions []int, ret *bitset) {
+	if low > high {
+		return
+	}
+	mid := (low + high) / 2
+
+	// no interval of subtree reaches the value
+	maxUpper := interval[T]{upper: t.maxUpper[mid]}
+	if !maxUpper.belowUpper(val) {
+		return
+	}
+
+	t.stabRange(low, mid-1, val, positions, ret)
+	current := t.intervals[mid]
+	if !current.aboveLower(val) {
+		// intervals of right subtree have higher lower bound
+		return
+	}
+	if current.belowUpper(val) {
+		ret.add(positions[current.order])
+	}
+	t.stabRange(mid+1, high, val, positions, ret)
+}
@@ -0,0 +1,96 @@
+package ruleenginecore
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_newInterval(t *testing.T) {
+	tests := []struct {
+		name   string
+		bounds []rangeBound
+		want   *interval[int64]
+	}{
+		{
+			name:   "unbounded",
+			bounds: []rangeBound{},
+			want:   &interval[int64]{lower: bound[int64]{unbounded: true}, upper: bound[int64]{unbounded: true}},
+		},
+		{
+			name: "band",
+			bounds: []rangeBound{
+				{cmp: cmpGreaterEqual, value: int64(10)},
+				{cmp: cmpLessEqual, value: int64(20)},
+			},
+			want: &interval[int64]{lower: bound[int64]{value: 10, inclusive: true}, upper: bound[int64]{value: 20, inclusive: true}},
+		},
+		{
+			name: "narrowestBounds",
+			bounds: []rangeBound{
+				{cmp: cmpGreaterEqual, value: int64(10)},
+				{cmp: cmpGreater, value: int64(10)},
+				{cmp: cmpGreater, value: int64(5)},
+				{cmp: cmpLess, value: int64(30)},
+				{cmp: cmpLessEqual, value: int64(20)},
+			},
+			want: &interval[int64]{lower: bound[int64]{value: 10}, upper: bound[int64]{value: 20, inclusive: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newInterval[int64](tt.bounds, 0); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_intervalTree_stab(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomBound := func() bound[int64] {
+		if random.Intn(5) == 0 {
+			return bound[int64]{unbounded: true}
+		}
+		return bound[int64]{value: int64(random.Intn(50)), inclusive: random.Intn(2) == 0}
+	}
+
+	intervals := []*interval[int64]{}
+	for i := 0; i < 300; i++ {
+		intervals = append(intervals, &interval[int64]{lower: randomBound(), upper: randomBound(), order: i})
+	}
+	tree := newIntervalTree(append([]*interval[int64]{}, intervals...))
+
+	for val := int64(-2); val <= 52; val++ {
+		want := []int{}
+		for _, i := range intervals {
+			if i.aboveLower(val) && i.belowUpper(val) {
+				want = append(want, i.order)
+			}
+		}
+
+		got := testStab(tree, val)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("intervalTree.stab(%v) = %v, want %v", val, got, want)
+		}
+	}
+
+	if got := testStab(newIntervalTree([]*interval[int64]{}), 1); len(got) != 0 {
+		t.Errorf("intervalTree.stab() of empty tree = %v, want []", got)
+	}
+}
+
+// returns ascending declaration orders of intervals containing the value, where rules are positioned as declared
+func testStab(tree *intervalTree[int64], val int64) []int {
+	positions := make([]int, len(tree.intervals))
+	for i := range positions {
+		positions[i] = i
+	}
+	set := newBitset(len(tree.intervals))
+	tree.stab(val, positions, &set)
+	ret := []int{}
+	for position := set.next(0); position >= 0; position = set.next(position + 1) {
+		ret = append(ret, position)
+	}
+	return ret
+}
@@ -0,0 +1,234 @@
+package ruleenginecore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// 'jsonPathNode' is a node of path tree prepared from field names, used to extract field values from JSON input.
+//
+// field name is considered as a path to value within JSON input, either as dotted path (ex. "user.tier") or as
+// JSON pointer (ex. "/user/tier"), array elements are referred by index (ex. "items.0.price")
+type jsonPathNode struct {
+	children map[string]*jsonPathNode
+
+	// fields having path ending at this node
+	fields []string
+}
+
+func newJSONPathTree(fs Fields) *jsonPathNode {
+	root := &jsonPathNode{children: map[string]*jsonPathNode{}}
+	for fieldName := range fs {
+		node := root
+		for _, segment := range splitFieldPath(fieldName) {
+			child, ok := node.children[segment]
+			if !ok {
+				child = &jsonPathNode{children: map[string]*jsonPathNode{}}
+				node.children[segment] = child
+			}
+			node = child
+		}
+		node.fields = append(node.fields, fieldName)
+	}
+	return root
+}
+
+// splits field name into path segments, field name starting with '/' is considered as JSON pointer
+func splitFieldPath(fieldName string) []string {
+	if !strings.HasPrefix(fieldName, "/") {
+		return strings.Split(fieldName, ".")
+	}
+
+	segments := strings.Split(fieldName[1:], "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+	}
+	return segments
+}
+
+type jsonExtractor struct {
+	decoder *json.Decoder
+	engine  *ruleEngine
+	result  *slotInput
+}
+
+// extracts value for the node, 'token' is first token of the value
+func (je *jsonExtractor) extract(node *jsonPathNode, token json.Token) *RuleEngineError {
+	delim, isDelim := token.(json.Delim)
+	if !isDelim || delim == '[' && len(node.fields) != 0 {
+		return je.captureValue(node, token)
+	}
+
+	if len(node.fields) != 0 {
+		return newError(ErrCodeInvalidValueType,
+			fmt.Sprintf("Expecting value for fields: %v, got JSON object", node.fields))
+	}
+
+	index := 0
+	for je.decoder.More() {
+		var key string
+		if delim == '{' {
+			keyToken, err := je.decoder.Token()
+			if err != nil {
+				return newError(ErrCodeParsingFailed).wrap(err)
+			}
+			key = keyToken.(string)
+		} else {
+			key = strconv.Itoa(index)
+			index++
+		}
+
+		valueToken, err := je.decoder.Token()
+		if err != nil {
+			return newError(ErrCodeParsingFailed).wrap(err)
+		}
+
+		if child, ok := node.children[key]; ok {
+			if err := je.extract(child, valueToken); err != nil {
+				return err
+			}
+		} else if err := je.skip(valueToken); err != nil {
+			return err
+		}
+	}
+
+	// closing delim
+	if _, err := je.decoder.Token(); err != nil {
+		return newError(ErrCodeParsingFailed).wrap(err)
+	}
+	return nil
+}
+
+// skips value, 'token' is first token of the value
+func (je *jsonExtractor) skip(token json.Token) *RuleEngineError {
+	if delim, ok := token.(json.Delim); !ok || (delim != '{' && delim != '[') {
+		return nil
+	}
+
+	for depth := 1; depth > 0; {
+		token, err := je.decoder.Token()
+		if err != nil {
+			return newError(ErrCodeParsingFailed).wrap(err)
+		}
+		if delim, ok := token.(json.Delim); ok {
+			if delim == '{' || delim == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+	}
+	return nil
+}
+
+// captures scalar or array of scalars value for the fields of node, 'token' is first token of the value
+func (je *jsonExtractor) captureValue(node *jsonPathNode, token json.Token) *RuleEngineError {
+	var values []any
+	isList := false
+	if token == json.Delim('[') {
+		isList = true
+		values = []any{}
+		for je.decoder.More() {
+			elementToken, err := je.decoder.Token()
+			if err != nil {
+				return newError(ErrCodeParsingFailed).wrap(err)
+			}
+			if _, ok := elementToken.(json.Delim); ok {
+				return newError(ErrCodeInvalidValueType,
+					fmt.Sprintf("Expecting list of values for fields: %v, got nested JSON value", node.fields))
+			}
+			values = append(values, elementToken)
+		}
+		// closing delim
+		if _, err := je.decoder.Token(); err != nil {
+			return newError(ErrCodeParsingFailed).wrap(err)
+		}
+	}
+
+	for _, fieldName := range node.fields {
+		// null value is considered as missing field
+		if token == nil {
+			continue
+		}
+
+		fieldType := je.engine.fields[fieldName]
+		if fieldType.isList() != isList {
+			return newError(ErrCodeInvalidValueType,
+				fmt.Sprintf("Input for field: %v having type %v, got incompatible JSON value", fieldName, fieldType)).withField(fieldName)
+		}
+
+		if !isList {
+			val, err := je.engine.jsonValue(token, fieldType)
+			if err != nil {
+				err.addMsg(fmt.Sprintf("Input parsing failed for type %v", fieldType))
+				return err.withField(fieldName)
+			}
+			je.result.slots[je.engine.fieldSlots[fieldName]] = val
+			continue
+		}
+
+		list := make([]any, len(values))
+		for i, elementToken := range values {
+			val, err := je.engine.jsonValue(elementToken, fieldType.elementType())
+			if err != nil {
+				err.addMsg(fmt.Sprintf("Input parsing failed for type %v", fieldType))
+				return err.withField(fieldName)
+			}
+			list[i] = val
+		}
+		je.result.slots[je.engine.fieldSlots[fieldName]] = list
+	}
+	return nil
+}
+
+// converts JSON scalar token to typed value as per valueType
+func (re *ruleEngine) jsonValue(token json.Token, valueType ValueType) (any, *RuleEngineError) {
+	switch val := token.(type) {
+	case bool:
+		if valueType == Boolean {
+			return val, nil
+		}
+	case json.Number:
+		if valueType == Integer || valueType == Float {
+			return parseValue(val.String(), valueType)
+		}
+	case string:
+		switch valueType {
+		case String, DateTime, Duration:
+			return parseValueWithLayout(val, valueType, re.dateTimeLayout)
+		}
+	}
+	return nil, newError(ErrCodeInvalidValueType, fmt.Sprintf("JSON value: %v is not of valueType: %v", token, valueType))
+}
+
+func (re *ruleEngine) validateAndParseJSON(input []byte) (*slotInput, *RuleEngineError) {
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, newError(ErrCodeParsingFailed).wrap(err)
+	}
+	if token != json.Delim('{') {
+		return nil, newError(ErrCodeParsingFailed, "Expecting JSON object input")
+	}
+
+	extractor := &jsonExtractor{decoder: decoder, engine: re, result: re.newSlotInput()}
+	if err := extractor.extract(re.jsonPaths, token); err != nil {
+		return nil, err
+	}
+
+	// input is expected to have nothing but the object
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, newError(ErrCodeParsingFailed, "Expecting no data after JSON object input").wrap(err)
+	}
+
+	if err := re.resolveMissingFields(extractor.result); err != nil {
+		return nil, err
+	}
+	return extractor.result, nil
+}
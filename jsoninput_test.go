@@ -0,0 +1,193 @@
+package ruleenginecore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_splitFieldPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldName string
+		want      []string
+	}{
+		{
+			name:      "plainName",
+			fieldName: "totalAmount",
+			want:      []string{"totalAmount"},
+		},
+		{
+			name:      "dottedPath",
+			fieldName: "user.tier",
+			want:      []string{"user", "tier"},
+		},
+		{
+			name:      "jsonPointer",
+			fieldName: "/order/items/0/price",
+			want:      []string{"order", "items", "0", "price"},
+		},
+		{
+			name:      "jsonPointerEscaped",
+			fieldName: "/a~1b/c~0d",
+			want:      []string{"a/b", "c~d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitFieldPath(tt.fieldName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitFieldPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ruleEngine_validateAndParseJSON(t *testing.T) {
+	fields := Fields{
+		"user.tier":             String,
+		"/order/total":          Integer,
+		"order.items.0.price":   Float,
+		"user.roles":            StringList,
+		"user.createdAt":        DateTime,
+		"order.express":         Boolean,
+		"order.coupon":          String,
+		"order.shipping.window": Duration,
+	}
+	re := &ruleEngine{
+		fields: fields,
+		fieldOptions: map[string]*FieldOption{
+			"order.coupon":          {Nullable: true},
+			"order.shipping.window": {Default: testStringPtr("1h"), typedDefault: time.Hour},
+		},
+		dateTimeLayout: time.RFC3339,
+		jsonPaths:      newJSONPathTree(fields),
+		fieldSlots:     newFieldSlots(fields),
+		slotFields:     newSlotFields(fields),
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    parsedInput
+		wantErr *RuleEngineError
+	}{
+		{
+			name: "valid",
+			input: `{"user":{"tier":"gold","roles":["admin","ops"],"createdAt":"2024-01-02T03:04:05Z","ignored":{"a":[1,{"b":2}]}},
+				"order":{"total":1200,"express":true,"coupon":null,"items":[{"price":10.5},{"price":3}]}}`,
+			want: parsedInput{
+				"user.tier":             "gold",
+				"/order/total":          int64(1200),
+				"order.items.0.price":   10.5,
+				"user.roles":            []any{"admin", "ops"},
+				"user.createdAt":        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				"order.express":         true,
+				"order.shipping.window": time.Hour,
+			},
+		},
+		{
+			name:    "invalid_NotJSONObject",
+			input:   `[1,2]`,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+		{
+			name:    "invalid_MalformedJSON",
+			input:   `{"user":{"tier":"gold"`,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+		{
+			name:    "invalid_TrailingObject",
+			input:   `{"user":{"tier":"gold","roles":[],"createdAt":"2024-01-02T03:04:05Z"},"order":{"total":1,"express":true,"items":[{"price":1}]}} {}`,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+		{
+			name:    "invalid_TrailingGarbage",
+			input:   `{"user":{"tier":"gold","roles":[],"createdAt":"2024-01-02T03:04:05Z"},"order":{"total":1,"express":true,"items":[{"price":1}]}}x`,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+		{
+			name:    "invalid_ValueType",
+			input:   `{"user":{"tier":1,"roles":[],"createdAt":"2024-01-02T03:04:05Z"},"order":{"total":1,"express":true,"items":[{"price":1}]}}`,
+			wantErr: newError(ErrCodeInvalidValueType),
+		},
+		{
+			name:    "invalid_ObjectForScalarField",
+			input:   `{"user":{"tier":{"name":"gold"}}}`,
+			wantErr: newError(ErrCodeInvalidValueType),
+		},
+		{
+			name:    "invalid_FieldNotFound",
+			input:   `{"user":{"tier":"gold","roles":[],"createdAt":"2024-01-02T03:04:05Z"},"order":{"express":true,"items":[{"price":1}]}}`,
+			wantErr: newError(ErrCodeFieldNotFound),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := re.validateAndParseJSON([]byte(tt.input))
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("validateAndParseJSON() gotErr %v, wantErr %v", gotErr, tt.wantErr)
+				return
+			}
+			if gotErr == nil && !reflect.DeepEqual(testParsedValues(got), tt.want) {
+				t.Errorf("validateAndParseJSON() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleEngine_EvaluateJSON(t *testing.T) {
+	engine, err := New(&RuleEngineConfig{
+		Fields: Fields{
+			"user.tier":   String,
+			"order.total": Integer,
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"goldUser": {
+				Operator: EqualOperator,
+				Operands: []*Operand{
+					{Type: Field, ValueType: String, Val: "user.tier"},
+					{Type: Constant, ValueType: String, Val: "gold"},
+				},
+			},
+			"bigOrder": {
+				Operator: GreaterOperator,
+				Operands: []*Operand{
+					{Type: Field, ValueType: Integer, Val: "order.total"},
+					{Type: Constant, ValueType: Integer, Val: "1000"},
+				},
+			},
+		},
+		Rules: map[string]*RuleConfig{
+			"goldBigOrder": {
+				Priority: 1,
+				RootCondition: &Condition{
+					Type: AndCondition,
+					SubConditions: []*Condition{
+						{Type: "goldUser"},
+						{Type: "bigOrder"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+
+	got, gotErr := engine.EvaluateJSON(context.TODO(), []byte(`{"user":{"tier":"gold"},"order":{"total":1200}}`), EvaluateOptions().Complete())
+	if gotErr != nil {
+		t.Fatalf("EvaluateJSON() gotErr %v", gotErr)
+	}
+	if len(got) != 1 || got[0].Rulename != "goldBigOrder" {
+		t.Errorf("EvaluateJSON() got %v, want [goldBigOrder]", got)
+	}
+
+	single, gotErr := engine.EvaluateSingleRuleJSON(context.TODO(), []byte(`{"user":{"tier":"silver"},"order":{"total":1200}}`), "goldBigOrder")
+	if gotErr != nil {
+		t.Fatalf("EvaluateSingleRuleJSON() gotErr %v", gotErr)
+	}
+	if single != nil {
+		t.Errorf("EvaluateSingleRuleJSON() got %v, want nil", single)
+	}
+}
@@ -0,0 +1,201 @@
+package ruleenginecore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Diagnostic kinds reported by 'Lint'
+const (
+	// field is not an operand of any condition type used by rules, hence input is not required to have the field,
+	// reported with SeverityInfo
+	UnusedField DiagnosticKind = "unusedField"
+
+	// condition type is not used by any rule, reported with SeverityInfo
+	UnusedConditionType DiagnosticKind = "unusedConditionType"
+
+	// rule matches only if a rule, or any of the rules, evaluated before it (lower priority value) matches, hence it is
+	// never an outcome of 'AscendingPriorityBased(1)' evaluation, reported with SeverityWarning
+	ShadowedRule DiagnosticKind = "shadowedRule"
+
+	// rules have same priority, hence their order is decided by tie break policy, reported with SeverityWarning or
+	// SeverityError for TieBreakError policy
+	PriorityCollision DiagnosticKind = "priorityCollision"
+)
+
+// 'Lint' reports fields and condition types which are not used by any rule, rules shadowed by rules having higher
+// priority and rules having same priority. Diagnostics are ordered as fields, condition types and shadowed rules, each
+// ordered by name, followed by priority collisions in ascending priority order.
+//
+// Shadowed rules are found by analyzing rule conditions same as 'Analyze', rules which can not be analyzed are not
+// reported. Rules having same priority are ordered as per tie break policy (see 'WithTieBreak') to find shadowed
+// rules, except for TieBreakError policy.
+//
+// Configuration is validated same as 'New', error is returned for invalid configuration.
+func Lint(engineConfig *RuleEngineConfig, opts ...EngineOption) ([]*Diagnostic, error) {
+	engineOp := newEngineOption(opts...)
+	// validation parses values into the configuration, caller's configuration is kept as is
+	engineConfig = engineConfig.clone()
+	if err := engineOp.registry.validator.validate(engineConfig, engineOp.dateTimeLayout); err != nil {
+		return nil, err
+	}
+
+	usedConditionTypes := map[string]bool{}
+	for _, ruleConfig := range engineConfig.Rules {
+		collectConditionTypes(ruleConfig.RootCondition, usedConditionTypes)
+	}
+
+	usedFields := map[string]bool{}
+	for conditionTypeName := range usedConditionTypes {
+		for _, operand := range engineConfig.ConditionTypes[conditionTypeName].Operands {
+			if operand.isField() {
+				usedFields[operand.Val] = true
+			}
+		}
+	}
+
+	ret := []*Diagnostic{}
+	for _, fieldName := range sortedKeys(engineConfig.Fields) {
+		if usedFields[fieldName] {
+			continue
+		}
+		ret = append(ret, &Diagnostic{Kind: UnusedField, Severity: SeverityInfo, Path: configPath("fields", fieldName),
+			Message: "field is not used by any rule"})
+	}
+
+	for _, conditionTypeName := range sortedKeys(engineConfig.ConditionTypes) {
+		if !usedConditionTypes[conditionTypeName] {
+			ret = append(ret, &Diagnostic{Kind: UnusedConditionType, Severity: SeverityInfo,
+				Path: configPath("conditionTypes", conditionTypeName), Message: "condition type is not used by any rule"})
+		}
+	}
+
+	a := &analyzer{config: engineConfig}
+	ret = append(ret, a.shadowedRules(engineOp.tieBreak)...)
+
+	for _, collision := range priorityCollisions(engineConfig.Rules) {
+		diagnostic := &Diagnostic{Kind: PriorityCollision, Severity: SeverityWarning, Path: "rules"}
+		switch engineOp.tieBreak {
+		case TieBreakByName:
+			diagnostic.Message = collision.String() + ", rules are ordered by name"
+		case TieBreakByDeclarationOrder:
+			diagnostic.Message = collision.String() + ", rules are ordered as declared"
+		case TieBreakError:
+			diagnostic.Severity = SeverityError
+			diagnostic.Message = collision.String() + ", rules having same priority are not allowed"
+		}
+		ret = append(ret, diagnostic)
+	}
+	return ret, nil
+}
+
+// adds custom condition types of the condition tree to 'ret'
+func collectConditionTypes(condition *Condition, ret map[string]bool) {
+	switch condition.Type {
+	case AndCondition, OrCondition, NegationCondition:
+	default:
+		ret[condition.Type] = true
+	}
+
+	for _, subCondition := range condition.SubConditions {
+		collectConditionTypes(subCondition, ret)
+	}
+}
+
+// reports rules, which match only if a rule evaluated before matches. Rule is considered shadowed by the first such
+// rule in evaluation order, rules having same priority are ordered as per tie break policy. Otherwise rule is
+// considered shadowed by rules evaluated before, if every input satisfying the rule satisfies any of them.
+func (a *analyzer) shadowedRules(tieBreak TieBreakPolicy) []*Diagnostic {
+	declarationOrder := ruleDeclarationOrder(a.config)
+	rules := make(map[string]*rule, len(a.config.Rules))
+	ruleNames := sortedKeys(a.config.Rules)
+	for _, ruleName := range ruleNames {
+		rules[ruleName] = &rule{name: ruleName, priority: a.config.Rules[ruleName].Priority,
+			order: declarationOrder[ruleName]}
+	}
+	sort.Slice(ruleNames, func(i, j int) bool {
+		return tieBreak.less(rules[ruleNames[i]], rules[ruleNames[j]])
+	})
+
+	// boxes of inputs satisfying and not satisfying a rule, rule which can not be analyzed is not part of it
+	satisfying := make(map[string][]box, len(ruleNames))
+	unsatisfying := make(map[string][]box, len(ruleNames))
+	for _, ruleName := range ruleNames {
+		condition := a.config.Rules[ruleName].RootCondition
+		if boxes, ok := a.boxes(condition, false); ok {
+			satisfying[ruleName] = boxes
+		}
+		if boxes, ok := a.boxes(condition, true); ok {
+			unsatisfying[ruleName] = boxes
+		}
+	}
+
+	ret := []*Diagnostic{}
+	for _, ruleName := range sortedKeys(a.config.Rules) {
+		ruleSatisfying, ok := satisfying[ruleName]
+
+		// unsatisfiable rule is reported by 'Analyze'
+		if !ok || len(ruleSatisfying) == 0 {
+			continue
+		}
+
+		// rules evaluated before, which can be analyzed
+		shadowingRuleNames := []string{}
+		for _, shadowingRuleName := range ruleNames {
+			if shadowingRuleName == ruleName {
+				break
+			}
+			if tieBreak == TieBreakError && rules[shadowingRuleName].priority == rules[ruleName].priority {
+				continue
+			}
+			if _, ok := unsatisfying[shadowingRuleName]; ok {
+				shadowingRuleNames = append(shadowingRuleNames, shadowingRuleName)
+			}
+		}
+
+		if message, ok := a.shadowedMessage(ruleSatisfying, shadowingRuleNames, satisfying, unsatisfying); ok {
+			ret = append(ret, &Diagnostic{Kind: ShadowedRule, Severity: SeverityWarning, RuleName: ruleName,
+				Path: configPath("rules", ruleName), Message: message})
+		}
+	}
+	return ret
+}
+
+// returns message describing how the rule having 'ruleSatisfying' boxes is shadowed by 'shadowingRuleNames', false if
+// it is not shadowed
+func (a *analyzer) shadowedMessage(ruleSatisfying []box, shadowingRuleNames []string, satisfying,
+	unsatisfying map[string][]box) (string, bool) {
+	// rule is shadowed if no input satisfies the rule without satisfying the shadowing rule
+	for _, shadowingRuleName := range shadowingRuleNames {
+		if boxes, ok := intersectBoxes(ruleSatisfying, unsatisfying[shadowingRuleName]); ok && len(boxes) == 0 {
+			return fmt.Sprintf("rule matches only if rule %v evaluated before matches, hence it is shadowed",
+				shadowingRuleName), true
+		}
+	}
+
+	// rule is shadowed if no input satisfies the rule without satisfying any of the shadowing rules, boxes of inputs
+	// satisfying the rule are narrowed down to inputs not satisfying rules evaluated before. Shadowing rules are the
+	// ones narrowing it down.
+	remaining := ruleSatisfying
+	shadowing := []string{}
+	for _, shadowingRuleName := range shadowingRuleNames {
+		if shadowingSatisfying, ok := satisfying[shadowingRuleName]; ok {
+			if boxes, ok := intersectBoxes(remaining, shadowingSatisfying); ok && len(boxes) == 0 {
+				continue
+			}
+		}
+
+		boxes, ok := intersectBoxes(remaining, unsatisfying[shadowingRuleName])
+		if !ok {
+			continue
+		}
+		remaining = boxes
+		shadowing = append(shadowing, shadowingRuleName)
+		if len(remaining) == 0 {
+			return fmt.Sprintf("rule matches only if any of rules %v evaluated before matches, hence it is shadowed",
+				strings.Join(shadowing, ", ")), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,272 @@
+package ruleenginecore
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	type diagnostic struct {
+		kind     DiagnosticKind
+		severity Severity
+		path     string
+	}
+	tests := []struct {
+		name  string
+		rules map[string]*RuleConfig
+		opts  []EngineOption
+		want  []diagnostic
+	}{
+		{
+			name: "Shadowed",
+			rules: map[string]*RuleConfig{
+				"highAmount": {Priority: 1, RootCondition: testCondition("amountMoreThan50")},
+				"veryHigh":   {Priority: 2, RootCondition: testCondition("amountMoreThan100")},
+				"lowAmount":  {Priority: 3, RootCondition: testCondition("amountAtMost100")},
+			},
+			want: []diagnostic{{ShadowedRule, SeverityWarning, `rules["veryHigh"]`}},
+		},
+		{
+			name: "Shadowed_ByTautology",
+			rules: map[string]*RuleConfig{
+				"any": {Priority: 1, RootCondition: testCondition(OrCondition, testCondition("premium"),
+					testCondition("notPremium"))},
+				"metro": {Priority: 2, RootCondition: testCondition("cityMetro")},
+			},
+			want: []diagnostic{{ShadowedRule, SeverityWarning, `rules["metro"]`}},
+		},
+		{
+			name: "Shadowed_Opaque",
+			rules: map[string]*RuleConfig{
+				"containsA": {Priority: 1, RootCondition: testCondition("cityContainsA")},
+				"containsAInMetro": {Priority: 2, RootCondition: testCondition(AndCondition, testCondition("cityMetro"),
+					testCondition("cityContainsA"))},
+			},
+			want: []diagnostic{{ShadowedRule, SeverityWarning, `rules["containsAInMetro"]`}},
+		},
+		{
+			name: "Shadowed_SamePriority",
+			rules: map[string]*RuleConfig{
+				"highAmount": {Priority: 1, RootCondition: testCondition("amountMoreThan50")},
+				"veryHigh":   {Priority: 1, RootCondition: testCondition("amountMoreThan100")},
+			},
+			want: []diagnostic{{ShadowedRule, SeverityWarning, `rules["veryHigh"]`}},
+		},
+		{
+			name: "Shadowed_ByRules",
+			rules: map[string]*RuleConfig{
+				"premium":    {Priority: 1, RootCondition: testCondition("premium")},
+				"notPremium": {Priority: 2, RootCondition: testCondition("notPremium")},
+				"metro":      {Priority: 3, RootCondition: testCondition("cityMetro")},
+			},
+			want: []diagnostic{{ShadowedRule, SeverityWarning, `rules["metro"]`}},
+		},
+		{
+			name: "Shadowed_ByRanges",
+			rules: map[string]*RuleConfig{
+				"lowAmount":  {Priority: 1, RootCondition: testCondition("amountAtMost100")},
+				"highAmount": {Priority: 2, RootCondition: testCondition("amountMoreThan100")},
+				"anyAmount":  {Priority: 3, RootCondition: testCondition("amountMoreThan5")},
+			},
+			want: []diagnostic{{ShadowedRule, SeverityWarning, `rules["anyAmount"]`}},
+		},
+		{
+			name: "NotShadowed_ByRanges",
+			rules: map[string]*RuleConfig{
+				"lessThan50": {Priority: 1, RootCondition: testCondition("amountLessThan50")},
+				"moreThan50": {Priority: 2, RootCondition: testCondition("amountMoreThan50")},
+				"anyAmount":  {Priority: 3, RootCondition: testCondition("amountMoreThan5")},
+			},
+			want: []diagnostic{},
+		},
+		{
+			name: "NotShadowed_SamePriorityOrderedBefore",
+			rules: map[string]*RuleConfig{
+				"highAmount": {Priority: 1, RootCondition: testCondition("amountMoreThan100")},
+				"veryHigh":   {Priority: 1, RootCondition: testCondition("amountMoreThan50")},
+			},
+			want: []diagnostic{},
+		},
+		{
+			name: "NotShadowed_SamePriorityTieBreakError",
+			rules: map[string]*RuleConfig{
+				"highAmount": {Priority: 1, RootCondition: testCondition("amountMoreThan50")},
+				"veryHigh":   {Priority: 1, RootCondition: testCondition("amountMoreThan100")},
+			},
+			opts: []EngineOption{WithTieBreak(TieBreakError)},
+			want: []diagnostic{},
+		},
+		{
+			name: "NotShadowed_LowerPriority",
+			rules: map[string]*RuleConfig{
+				"veryHigh":   {Priority: 1, RootCondition: testCondition("amountMoreThan100")},
+				"highAmount": {Priority: 2, RootCondition: testCondition("amountMoreThan50")},
+			},
+			want: []diagnostic{},
+		},
+		{
+			name: "NotShadowed_Unsatisfiable",
+			rules: map[string]*RuleConfig{
+				"highAmount": {Priority: 1, RootCondition: testCondition("amountMoreThan50")},
+				"never": {Priority: 2, RootCondition: testCondition(AndCondition, testCondition("amountMoreThan100"),
+					testCondition("amountLessThan50"))},
+			},
+			want: []diagnostic{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testAnalyzerConfig(nil)
+			config.Rules = tt.rules
+			got, gotErr := Lint(config, tt.opts...)
+			if gotErr != nil {
+				t.Fatalf("Lint() gotErr %v", gotErr)
+			}
+
+			gotDiagnostics := []diagnostic{}
+			for _, d := range got {
+				// unused fields and condition types are considered by TestLint_Unused
+				if d.Kind != ShadowedRule {
+					continue
+				}
+				gotDiagnostics = append(gotDiagnostics, diagnostic{d.Kind, d.Severity, d.Path})
+			}
+			if !reflect.DeepEqual(gotDiagnostics, tt.want) {
+				t.Errorf("Lint() got %v, want %v", gotDiagnostics, tt.want)
+			}
+		})
+	}
+}
+
+func TestLint_ShadowedMessage(t *testing.T) {
+	config := testAnalyzerConfig(nil)
+	config.Rules = map[string]*RuleConfig{
+		"premium":    {Priority: 1, RootCondition: testCondition("premium")},
+		"delhi":      {Priority: 2, RootCondition: testCondition("cityDelhi")},
+		"notPremium": {Priority: 3, RootCondition: testCondition("notPremium")},
+		"highAmount": {Priority: 4, RootCondition: testCondition("amountMoreThan50")},
+		"veryHigh":   {Priority: 5, RootCondition: testCondition("amountMoreThan100")},
+		"pune":       {Priority: 6, RootCondition: testCondition("cityPune")},
+	}
+	got, gotErr := Lint(config)
+	if gotErr != nil {
+		t.Fatalf("Lint() gotErr %v", gotErr)
+	}
+
+	gotMessages := map[string]string{}
+	for _, d := range got {
+		if d.Kind == ShadowedRule {
+			gotMessages[d.RuleName] = d.Message
+		}
+	}
+	want := map[string]string{
+		"highAmount": "rule matches only if any of rules premium, delhi, notPremium evaluated before matches, hence it is shadowed",
+		"pune":       "rule matches only if any of rules premium, notPremium evaluated before matches, hence it is shadowed",
+		"veryHigh":   "rule matches only if rule highAmount evaluated before matches, hence it is shadowed",
+	}
+	if !reflect.DeepEqual(gotMessages, want) {
+		t.Errorf("Lint() got %v, want %v", gotMessages, want)
+	}
+}
+
+func TestLint_Unused(t *testing.T) {
+	config := testAnalyzerConfig(map[string]*Condition{
+		"rule": testCondition(AndCondition, testCondition("amountMoreThan100"),
+			testCondition(NegationCondition, testCondition("cityDelhi"))),
+	})
+	got, gotErr := Lint(config)
+	if gotErr != nil {
+		t.Fatalf("Lint() gotErr %v", gotErr)
+	}
+
+	want := []*Diagnostic{
+		{Kind: UnusedField, Severity: SeverityInfo, Path: `fields["discount"]`, Message: "field is not used by any rule"},
+		{Kind: UnusedField, Severity: SeverityInfo, Path: `fields["premium"]`, Message: "field is not used by any rule"},
+		{Kind: UnusedField, Severity: SeverityInfo, Path: `fields["price"]`, Message: "field is not used by any rule"},
+	}
+	for _, conditionTypeName := range sortedKeys(config.ConditionTypes) {
+		if conditionTypeName != "amountMoreThan100" && conditionTypeName != "cityDelhi" {
+			want = append(want, &Diagnostic{Kind: UnusedConditionType, Severity: SeverityInfo,
+				Path: `conditionTypes["` + conditionTypeName + `"]`, Message: "condition type is not used by any rule"})
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() got %v, want %v", got, want)
+	}
+}
+
+func TestLint_PriorityCollision(t *testing.T) {
+	config := testAnalyzerConfig(nil)
+	config.Rules = map[string]*RuleConfig{
+		"amountRule": {Priority: 2, RootCondition: testCondition("amountMoreThan100")},
+		"cityRule":   {Priority: 2, RootCondition: testCondition("cityDelhi")},
+		"metroRule":  {Priority: 1, RootCondition: testCondition("cityMetro")},
+		"priceRule":  {Priority: 1, RootCondition: testCondition("priceMoreThan5")},
+		"puneRule":   {Priority: 3, RootCondition: testCondition("cityPune")},
+	}
+
+	tests := []struct {
+		name string
+		opts []EngineOption
+		want []*Diagnostic
+	}{
+		{
+			name: "TieBreakByName",
+			want: []*Diagnostic{
+				{Kind: PriorityCollision, Severity: SeverityWarning, Path: "rules",
+					Message: "rules: metroRule,priceRule have priority: 1, rules are ordered by name"},
+				{Kind: PriorityCollision, Severity: SeverityWarning, Path: "rules",
+					Message: "rules: amountRule,cityRule have priority: 2, rules are ordered by name"},
+			},
+		},
+		{
+			name: "TieBreakError",
+			opts: []EngineOption{WithTieBreak(TieBreakError)},
+			want: []*Diagnostic{
+				{Kind: PriorityCollision, Severity: SeverityError, Path: "rules",
+					Message: "rules: metroRule,priceRule have priority: 1, rules having same priority are not allowed"},
+				{Kind: PriorityCollision, Severity: SeverityError, Path: "rules",
+					Message: "rules: amountRule,cityRule have priority: 2, rules having same priority are not allowed"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := Lint(config, tt.opts...)
+			if gotErr != nil {
+				t.Fatalf("Lint() gotErr %v", gotErr)
+			}
+
+			gotCollisions := []*Diagnostic{}
+			for _, d := range got {
+				if d.Kind == PriorityCollision {
+					gotCollisions = append(gotCollisions, d)
+				}
+			}
+			if !reflect.DeepEqual(gotCollisions, tt.want) {
+				t.Errorf("Lint() got %v, want %v", gotCollisions, tt.want)
+			}
+		})
+	}
+}
+
+func TestLint_InvalidConfig(t *testing.T) {
+	config := testAnalyzerConfig(map[string]*Condition{"rule": testCondition("unknown")})
+	if _, gotErr := Lint(config); !errors.Is(gotErr, ErrConditionTypeNotFound) {
+		t.Errorf("Lint() gotErr %v, wantErr %v", gotErr, ErrConditionTypeNotFound)
+	}
+}
+
+func TestDiagnostic_JSON(t *testing.T) {
+	got, err := json.Marshal(&Diagnostic{Kind: UnusedField, Severity: SeverityWarning, Path: `fields["amount"]`,
+		Message: "field is not used by any rule"})
+	if err != nil {
+		t.Fatalf("json.Marshal() err %v", err)
+	}
+	want := `{"kind":"unusedField","severity":"warning","path":"fields[\"amount\"]","message":"field is not used by any rule"}`
+	if string(got) != want {
+		t.Errorf("json.Marshal() got %v, want %v", string(got), want)
+	}
+}
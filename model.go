@@ -1,19 +1,35 @@
 package ruleenginecore
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
-	"strings"
+	"time"
 )
 
 // Supported operators to define custom ConditionType
 const (
-	GreaterOperator      = ">"
-	GreaterEqualOperator = ">="
-	LessOperator         = "<"
-	LessEqualOperator    = "<="
-	EqualOperator        = "=="
-	NotEqualOperator     = "!="
-	ContainOperator      = "contain"
+	GreaterOperator          = ">"
+	GreaterEqualOperator     = ">="
+	LessOperator             = "<"
+	LessEqualOperator        = "<="
+	EqualOperator            = "=="
+	NotEqualOperator         = "!="
+	ContainOperator          = "contain"
+	MatchOperator            = "matches" // matches anywhere within the value unless anchored with '^' and '$'
+	InOperator               = "in"
+	NotInOperator            = "notIn"
+	AnyOfOperator            = "anyOf"
+	AllOfOperator            = "allOf"
+	NoneOfOperator           = "noneOf"
+	SizeEqualOperator        = "size=="
+	SizeNotEqualOperator     = "size!="
+	SizeGreaterOperator      = "size>"
+	SizeGreaterEqualOperator = "size>="
+	SizeLessOperator         = "size<"
+	SizeLessEqualOperator    = "size<="
+	IsPresentOperator        = "isPresent"
+	IsMissingOperator        = "isMissing"
 )
 
 // Supported default ConditionTypes
@@ -26,6 +42,20 @@ const (
 // 'Input' defines an input for rule evaluation as map of fieldname as key and string representation of value as (map)value
 type Input map[string]string
 
+// 'TypedInput' defines an input for rule evaluation as map of fieldname as key and typed value as (map)value
+//
+// expected value types as per field ValueType are:
+//
+//	'Boolean' -> bool
+//	'String' -> string
+//	'Integer' -> int64, int, int32, int16, int8, uint32, uint16, uint8
+//	'Float' -> float64, float32
+//	'DateTime' -> time.Time
+//	'Duration' -> time.Duration
+//	list valueTypes -> slice of any respective element type (ex. []string for 'StringList', []int or []int64 for
+//	'IntegerList') or []any
+type TypedInput map[string]any
+
 // defines an output for evaluation result
 type Output struct {
 	// matched rulename
@@ -36,6 +66,9 @@ type Output struct {
 
 	// matched rule result, defined as part of RuleEngineConfig for every rule
 	Result map[string]any `json:"result"`
+
+	// version of rule engine configuration, which evaluated the rule. It is set by ReloadableEngine only.
+	Version uint64 `json:"version,omitempty"`
 }
 
 func newOutput(ruleName string, priority int, result map[string]any) *Output {
@@ -48,7 +81,12 @@ func newOutput(ruleName string, priority int, result map[string]any) *Output {
 
 // 'fields' defines a mandatory input for RuleEngine evaluation, internally it represents as map of fieldname(string) as key and ValueType as value
 //
-// As part engine evaluation, for field value is picked from the input with fieldname.
+// As part engine evaluation, for field value is picked from the input with fieldname. Input is not required to have a
+// field, which is not an operand of any rule.
+// Field names must not be empty or start with '$', which is reserved.
+//
+// For JSON input, field name is considered as path to the value, either as dotted path (ex. "user.tier") or as
+// JSON pointer (ex. "/user/tier").
 type Fields map[string]ValueType
 
 func (fs Fields) exist(fieldName string, expectedFieldType ValueType) bool {
@@ -64,6 +102,20 @@ func (fs Fields) exist(fieldName string, expectedFieldType ValueType) bool {
 	return true
 }
 
+// 'FieldOption' defines optional behaviour for a field, input without the field is allowed for a field having option.
+// Either 'Default' or 'Nullable' is expected to be set. Field without option is mandatory, if it is an operand of any
+// rule.
+type FieldOption struct {
+	// 'Default' is value in a string form, considered as field value when input does not have the field
+	Default *string `json:"default,omitempty"`
+
+	// 'Nullable' allows input without the field, conditions having missing field operand evaluates to false (except
+	// 'isMissing'). Negation is applied on the outcome, hence 'not' of such condition evaluates to true.
+	Nullable bool `json:"nullable,omitempty"`
+
+	typedDefault any `json:"-"`
+}
+
 // 'Operand' defines an operand for custom ConditionType
 //
 // as part of evaluation process operand value is determined as following:
@@ -72,6 +124,8 @@ func (fs Fields) exist(fieldName string, expectedFieldType ValueType) bool {
 //		->operand.Val is fieldname, Operand value is determined from the input having fieldname as <operand.Val>
 //	if operand.OperandType is 'constant'
 //		->operand.Val is considered as operand value in a string form.
+//	if operand.OperandType is 'now'
+//		->operand.Val is an optional duration(ex. "-30d"), Operand value is evaluation time shifted by the duration.
 type Operand struct {
 	// 'ValueType' defined as type of operand value
 	ValueType ValueType `json:"valuetype"`
@@ -85,9 +139,14 @@ type Operand struct {
 	//		-> Val is considered as 'fieldname', while evaluation, value is picked from input as operand for evaluation
 	// for OperandType as 'constant'
 	//		-> Val is considered as value and picked as operand for evaluation.
+	// for OperandType as 'now'
+	//		-> Val is considered as duration, evaluation time shifted by the duration is picked as operand for evaluation.
 
 	Val        string `json:"value"`
 	typedValue any    `json:"-"`
+
+	// values of list constant in declared order, typedValue maintains such values as a set
+	typedValues []any
 }
 
 func (op *Operand) isField() bool {
@@ -100,22 +159,60 @@ func (op *Operand) getValue(values parsedInput) any {
 		return values[op.Val]
 	case Constant:
 		return op.typedValue
+	case Now:
+		return values[nowFieldName].(time.Time).Add(op.typedValue.(time.Duration))
 	}
 	// no-op
 	panic(fmt.Sprintf("Invalid OperandType %v", op.ValueType))
 }
 
 // 'ConditionType' defines a custom condition type, which is be used while defining a rule
-// Valid Operators are '>','>=','<','<=','==', '!=', 'contain'
+// Valid Operators are '>','>=','<','<=','==', '!=', 'contain', 'matches', 'in', 'notIn', 'anyOf', 'allOf', 'noneOf',
+// 'size==', 'size!=', 'size>', 'size>=', 'size<', 'size<=', 'isPresent', 'isMissing'
 //
-//	'>','>=','<','<=' operators supports 'int', 'float' operand valueType
-//	'==', '!=' operator support 'int','float','bool','string' operand valueType
+//	'>','>=','<','<=' operators supports 'int', 'float', 'datetime', 'duration' operand valueType
+//	'==', '!=' operator support 'int','float','bool','string', 'datetime', 'duration' operand valueType
 //	'contain' operator supports 'string' operand valueType
+//	'matches' operator supports 'string' operand valueType, second operand is a constant regular expression(RE2 syntax),
+//		which matches a substring of first operand (ex. "abc" matches "xxabcxx"), use "^abc$" to match whole value
+//	'in', 'notIn' operators support 'int','float','bool','string' operand valueType, second operand is a constant
+//		list of comma separated values (ex. "Bangalore,Delhi,Mumbai"), leading and trailing spaces of every value are ignored
+//	'anyOf', 'allOf', 'noneOf' operators support list operand valueType, first operand has any, all or none of
+//		the second operand values
+//	'size==', 'size!=', 'size>', 'size>=', 'size<', 'size<=' operators compare size of first list operand with
+//		second 'int' operand
+//	'isPresent', 'isMissing' operators support single field operand of any valueType, checks field is present in the
+//		input or missing, meaningful for nullable fields
+//
+// Condition having nullable field operand evaluates to false when the field is missing from the input, except for
+// 'isPresent' and 'isMissing' operators.
+//
+// Custom operators registered with 'OperatorRegistry' are valid operators as well, for the engine using the registry.
 type ConditionType struct {
 	Operator string     `json:"operator"`
 	Operands []*Operand `json:"operands"`
 }
 
+// returns copy of the condition type having copied operands
+func (ct *ConditionType) clone() *ConditionType {
+	if ct == nil {
+		return nil
+	}
+	ret := *ct
+
+	if ct.Operands != nil {
+		ret.Operands = make([]*Operand, len(ct.Operands))
+		for i, operand := range ct.Operands {
+			if operand != nil {
+				copied := *operand
+				operand = &copied
+			}
+			ret.Operands[i] = operand
+		}
+	}
+	return &ret
+}
+
 // 'Condition' define condition for a Rule which needs to be satisfy to consider rule a matched.
 type Condition struct {
 	// 'Type' sets type of a condition, either logical such as 'and','or','not' or types defined as 'ConditionTypes' with RuleEngineConfig
@@ -143,61 +240,110 @@ type RuleEngineConfig struct {
 	// 'Fields' defines mandatory as input for rule engine evaluation
 	Fields Fields `json:"fields"`
 
+	// 'FieldOptions' defines optional fields as map having field name as key, FieldOption as value
+	FieldOptions map[string]*FieldOption `json:"fieldOptions,omitempty"`
+
 	// 'ConditionTypes' defines custom condition as map having condition name as key, ConditionType as value
 	ConditionTypes map[string]*ConditionType `json:"conditionTypes"`
 
 	// 'Rules' defines set of rules for ruleengine, as map having rule name as key, RuleConfig as value
 	Rules map[string]*RuleConfig `json:"rules"`
+
+	// rule names in the order of declaration in JSON configuration, used by 'TieBreakByDeclarationOrder'
+	ruleOrder []string
 }
 
-type parsedInput map[string]any
+// returns copy of the configuration, which is validated and built into an engine. Validation parses constant operands
+// and field defaults into the copy, hence engine never shares mutable state with the configuration kept by the caller.
+// Rules are copied shallow, as conditions and results are never mutated.
+func (config *RuleEngineConfig) clone() *RuleEngineConfig {
+	if config == nil {
+		return nil
+	}
+	ret := *config
 
-type RuleEngineError struct {
-	ErrCode  uint
-	ErrMsg   string
-	OtherMsg string
-}
+	if config.Fields != nil {
+		ret.Fields = make(Fields, len(config.Fields))
+		for name, valueType := range config.Fields {
+			ret.Fields[name] = valueType
+		}
+	}
 
-func (ce *RuleEngineError) Error() string {
-	return fmt.Sprintf("RuleEngineError: ErrCode:%v ErrMsg:%v. %v", ce.ErrCode, ce.ErrMsg, ce.OtherMsg)
-}
+	if config.FieldOptions != nil {
+		ret.FieldOptions = make(map[string]*FieldOption, len(config.FieldOptions))
+		for name, option := range config.FieldOptions {
+			if option != nil {
+				copied := *option
+				option = &copied
+			}
+			ret.FieldOptions[name] = option
+		}
+	}
+
+	if config.ConditionTypes != nil {
+		ret.ConditionTypes = make(map[string]*ConditionType, len(config.ConditionTypes))
+		for name, ct := range config.ConditionTypes {
+			ret.ConditionTypes[name] = ct.clone()
+		}
+	}
 
-func (ce *RuleEngineError) addMsg(msg string) {
-	ce.OtherMsg = fmt.Sprintf("%v, %v", ce.OtherMsg, msg)
+	if config.Rules != nil {
+		ret.Rules = make(map[string]*RuleConfig, len(config.Rules))
+		for name, rule := range config.Rules {
+			ret.Rules[name] = rule
+		}
+	}
+	return &ret
 }
 
-func newError(errCode uint, msgs ...string) *RuleEngineError {
-	return &RuleEngineError{ErrCode: errCode, ErrMsg: errCodeToMessage[errCode], OtherMsg: strings.Join(msgs, ",")}
+func (config *RuleEngineConfig) UnmarshalJSON(data []byte) error {
+	type plainConfig RuleEngineConfig
+	if err := json.Unmarshal(data, (*plainConfig)(config)); err != nil {
+		return err
+	}
+
+	var rules struct {
+		Rules json.RawMessage `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return err
+	}
+
+	ruleOrder, err := jsonObjectKeys(rules.Rules)
+	if err != nil {
+		return err
+	}
+	config.ruleOrder = ruleOrder
+	return nil
 }
 
-const (
-	ErrCodeInvalidValueType = iota + 1
-	ErrCodeInvalidOperator
-	ErrCodeInvalidOperandType
-	ErrCodeInvalidOperandsLength
-	ErrCodeInvalidConditionType
-	ErrCodeInvalidSubConditionCount
-	ErrCodeConditionTypeNotFound
-	ErrCodeFieldNotFound
-	ErrCodeParsingFailed
-	ErrCodeRuleNotFound
-	ErrCodeInvalidEvaluateOperations
-	ErrCodeContextCancelled
-	ErrCodeInvalidOperand
-)
+// returns keys of JSON object in the order of declaration, nil for JSON other than an object
+func jsonObjectKeys(data json.RawMessage) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, nil
+	}
 
-var errCodeToMessage = map[uint]string{
-	ErrCodeInvalidValueType:          "Invalid ValueType",
-	ErrCodeInvalidOperator:           "Invalid operator",
-	ErrCodeInvalidOperandType:        "Invalid operandType",
-	ErrCodeInvalidOperandsLength:     "Invalid number of operands",
-	ErrCodeInvalidConditionType:      "Invalid conditionType",
-	ErrCodeInvalidSubConditionCount:  "Invalid sub-condition count",
-	ErrCodeConditionTypeNotFound:     "Could not find conditionType",
-	ErrCodeFieldNotFound:             "Field not found",
-	ErrCodeParsingFailed:             "Could not parse value",
-	ErrCodeRuleNotFound:              "Rule not found",
-	ErrCodeInvalidEvaluateOperations: "Invalid evaluate options value n",
-	ErrCodeContextCancelled:          "Context is cancelled",
-	ErrCodeInvalidOperand:            "Invalid operandtype or valuetype",
+	ret := []string{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		ret = append(ret, token.(string))
+	}
+	return ret, nil
 }
+
+type parsedInput map[string]any
+
+// field names starting with 'reservedFieldPrefix' are reserved for internal use
+const reservedFieldPrefix = "$"
+
+// parsedInput maintains evaluation time with 'nowFieldName' for 'Now' operands
+const nowFieldName = reservedFieldPrefix + "now"
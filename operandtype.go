@@ -6,7 +6,7 @@ import (
 	"strings"
 )
 
-// 'OperandType' defines type of operand either 'Field' or 'Constant'
+// 'OperandType' defines type of operand either 'Field', 'Constant' or 'Now'
 type OperandType uint8
 
 const (
@@ -17,22 +17,29 @@ const (
 
 	// 'Constant' is OperandType where operand value is considered as Operand.Val
 	Constant
+
+	// 'Now' is OperandType where operand value is evaluation time shifted by Operand.Val as duration (ex. "-720h", "-30d"),
+	// empty Operand.Val considers evaluation time as is. It supports only 'DateTime' valueType.
+	Now
 )
 
 var (
 	operandType_Name = map[OperandType]string{
 		1: "Field",
 		2: "Constant",
+		3: "Now",
 	}
 	operandType_Value = map[string]OperandType{
 		"field":    1,
 		"Field":    1,
 		"constant": 2,
 		"Constant": 2,
+		"now":      3,
+		"Now":      3,
 	}
 )
 
-// 'isValid' check for valid operandType starting from 1("Field"),2("Constant")
+// 'isValid' check for valid operandType starting from 1("Field"),2("Constant"),3("Now")
 func (operandType OperandType) isValid() bool {
 	_, ok := operandType_Name[operandType]
 	return ok
@@ -21,6 +21,11 @@ func TestOperandType_isValid(t *testing.T) {
 			operandType: Constant,
 			want:        true,
 		},
+		{
+			name:        "valid_Now",
+			operandType: Now,
+			want:        true,
+		},
 		{
 			name:        "invalid",
 			operandType: unknownOperandType,
@@ -109,6 +114,14 @@ func Test_parseOperandType(t *testing.T) {
 			want:    Constant,
 			wantErr: false,
 		},
+		{
+			name: "valid_now",
+			args: args{
+				s: "now",
+			},
+			want:    Now,
+			wantErr: false,
+		},
 		{
 			name: "invalid",
 			args: args{
@@ -1,7 +1,9 @@
 package ruleenginecore
 
 import (
+	"regexp"
 	"strings"
+	"time"
 )
 
 const (
@@ -11,7 +13,7 @@ const (
 	valuePrepFail = "Could not get value for given field"
 )
 
-func greater[T int64 | float64](input map[string]any, operands []*Operand) bool {
+func greater[T int64 | float64 | time.Duration](input map[string]any, operands []*Operand) bool {
 	first, ok := operands[firstOperand].getValue(input).(T)
 	if !ok {
 		panic(valuePrepFail)
@@ -23,7 +25,7 @@ func greater[T int64 | float64](input map[string]any, operands []*Operand) bool
 	return first > second
 }
 
-func greaterAndEqual[T int64 | float64](input map[string]any, operands []*Operand) bool {
+func greaterAndEqual[T int64 | float64 | time.Duration](input map[string]any, operands []*Operand) bool {
 	first, ok := operands[firstOperand].getValue(input).(T)
 	if !ok {
 		panic(valuePrepFail)
@@ -35,7 +37,7 @@ func greaterAndEqual[T int64 | float64](input map[string]any, operands []*Operan
 	return first >= second
 }
 
-func lesser[T int64 | float64](input map[string]any, operands []*Operand) bool {
+func lesser[T int64 | float64 | time.Duration](input map[string]any, operands []*Operand) bool {
 	first, ok := operands[firstOperand].getValue(input).(T)
 	if !ok {
 		panic(valuePrepFail)
@@ -48,7 +50,7 @@ func lesser[T int64 | float64](input map[string]any, operands []*Operand) bool {
 	return first < second
 }
 
-func lesserAndEqual[T int64 | float64](input map[string]any, operands []*Operand) bool {
+func lesserAndEqual[T int64 | float64 | time.Duration](input map[string]any, operands []*Operand) bool {
 	first, ok := operands[firstOperand].getValue(input).(T)
 	if !ok {
 		panic(valuePrepFail)
@@ -61,7 +63,7 @@ func lesserAndEqual[T int64 | float64](input map[string]any, operands []*Operand
 	return first <= second
 }
 
-func equal[T bool | int64 | float64 | string](input map[string]any, operands []*Operand) bool {
+func equal[T bool | int64 | float64 | string | time.Duration](input map[string]any, operands []*Operand) bool {
 	first, ok := operands[firstOperand].getValue(input).(T)
 	if !ok {
 		panic(valuePrepFail)
@@ -74,7 +76,7 @@ func equal[T bool | int64 | float64 | string](input map[string]any, operands []*
 	return first == second
 }
 
-func notEqual[T bool | int64 | float64 | string](input map[string]any, operands []*Operand) bool {
+func notEqual[T bool | int64 | float64 | string | time.Duration](input map[string]any, operands []*Operand) bool {
 	first, ok := operands[firstOperand].getValue(input).(T)
 	if !ok {
 		panic(valuePrepFail)
@@ -87,6 +89,19 @@ func notEqual[T bool | int64 | float64 | string](input map[string]any, operands
 	return first != second
 }
 
+// compares DateTime operands, returns -1 if first is before second, 0 if both are same point in time, +1 otherwise
+func compareTime(input map[string]any, operands []*Operand) int {
+	first, ok := operands[firstOperand].getValue(input).(time.Time)
+	if !ok {
+		panic(valuePrepFail)
+	}
+	second, ok := operands[secondOperand].getValue(input).(time.Time)
+	if !ok {
+		panic(valuePrepFail)
+	}
+	return first.Compare(second)
+}
+
 func contain(input map[string]any, operands []*Operand) bool {
 	first, ok := operands[firstOperand].getValue(input).(string)
 	if !ok {
@@ -98,3 +113,95 @@ func contain(input map[string]any, operands []*Operand) bool {
 	}
 	return strings.Contains(first, second)
 }
+
+func match(input map[string]any, operands []*Operand) bool {
+	first, ok := operands[firstOperand].getValue(input).(string)
+	if !ok {
+		panic(valuePrepFail)
+	}
+	pattern, ok := operands[secondOperand].getValue(input).(*regexp.Regexp)
+	if !ok {
+		panic(valuePrepFail)
+	}
+	return pattern.MatchString(first)
+}
+
+func in(input map[string]any, operands []*Operand) bool {
+	first := operands[firstOperand].getValue(input)
+	if first == nil {
+		panic(valuePrepFail)
+	}
+	values, ok := operands[secondOperand].getValue(input).(set[any])
+	if !ok {
+		panic(valuePrepFail)
+	}
+	return values.Contains(first)
+}
+
+// checks val is one of the values, where values are either list operand value or constant list operand value maintained as set
+func memberOf(values any, val any) bool {
+	switch v := values.(type) {
+	case set[any]:
+		return v.Contains(val)
+	case []any:
+		for _, element := range v {
+			if element == val {
+				return true
+			}
+		}
+		return false
+	}
+	panic(valuePrepFail)
+}
+
+// first list operand has at least one element of second list operand
+func anyOf(input map[string]any, operands []*Operand) bool {
+	first, ok := operands[firstOperand].getValue(input).([]any)
+	if !ok {
+		panic(valuePrepFail)
+	}
+	second := operands[secondOperand].getValue(input)
+	for _, element := range first {
+		if memberOf(second, element) {
+			return true
+		}
+	}
+	return false
+}
+
+// first list operand has all elements of second list operand
+func allOf(input map[string]any, operands []*Operand) bool {
+	first, ok := operands[firstOperand].getValue(input).([]any)
+	if !ok {
+		panic(valuePrepFail)
+	}
+	switch second := operands[secondOperand].getValue(input).(type) {
+	case set[any]:
+		for element := range second.internalMap {
+			if !memberOf(first, element) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		for _, element := range second {
+			if !memberOf(first, element) {
+				return false
+			}
+		}
+		return true
+	}
+	panic(valuePrepFail)
+}
+
+func size(input map[string]any, operands []*Operand) (int64, int64) {
+	first, ok := operands[firstOperand].getValue(input).([]any)
+	if !ok {
+		panic(valuePrepFail)
+	}
+	second, ok := operands[secondOperand].getValue(input).(int64)
+	if !ok {
+		panic(valuePrepFail)
+	}
+	return int64(len(first)), second
+}
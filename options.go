@@ -1,5 +1,7 @@
 package ruleenginecore
 
+import "time"
+
 type evaluationType uint
 
 const (
@@ -11,6 +13,42 @@ const (
 type evaluateOption struct {
 	evalType evaluationType
 	limit    int
+
+	// records evaluation trace if not nil
+	trace *Trace
+
+	// checks context once in every n condition nodes if greater than 0
+	cancellationCheckInterval int
+
+	// evaluates rules concurrently with given number of workers if greater than 1
+	workers int
+}
+
+// 'WithTrace' returns a copy of evaluation option, which records trace of every evaluated rule into 'trace'.
+// Trace explains the outcome of each condition, including conditions short-circuited by logical conditions.
+func (op *evaluateOption) WithTrace(trace *Trace) *evaluateOption {
+	ret := *op
+	ret.trace = trace
+	return &ret
+}
+
+// 'WithCancellationCheck' returns a copy of evaluation option, which checks context cancellation once in every 'n'
+// condition nodes while evaluating a rule. By default, context is checked only before evaluating each rule, which is
+// sufficient unless rules have large condition trees.
+func (op *evaluateOption) WithCancellationCheck(n int) *evaluateOption {
+	ret := *op
+	ret.cancellationCheckInterval = n
+	return &ret
+}
+
+// 'WithParallel' returns a copy of evaluation option, which evaluates rules concurrently with 'workers' goroutines,
+// each taking the next rule not yet evaluated. Evaluation stops on first error. Output is same as sequential
+// evaluation and ordered by priority. Priority based evaluation evaluates all rules before considering first n matched
+// rules. Evaluation having trace is always sequential.
+func (op *evaluateOption) WithParallel(workers int) *evaluateOption {
+	ret := *op
+	ret.workers = workers
+	return &ret
 }
 
 var completeEvalOption = evaluateOption{
@@ -52,6 +90,98 @@ func (e *evaluateOptionSelector) DescendingPriorityBased(n int) *evaluateOption
 //
 //  3. EvaluateOptions().DescendingPriorityBased(5)
 //     evaluate rules in descending priority order (ex: 10,9,8...) and returns top 5 matched rule as output
+//
+// Evaluation trace is recorded by setting 'WithTrace', ex. EvaluateOptions().Complete().WithTrace(&trace)
 func EvaluateOptions() *evaluateOptionSelector {
 	return &evaluateOpSelector
 }
+
+type engineOption struct {
+	registry       *OperatorRegistry
+	dateTimeLayout string
+	ruleIndex      bool
+	tieBreak       TieBreakPolicy
+}
+
+func newEngineOption(opts ...EngineOption) *engineOption {
+	op := &engineOption{
+		registry:       defaultRegistry,
+		dateTimeLayout: time.RFC3339,
+		tieBreak:       TieBreakByName,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+	return op
+}
+
+// 'EngineOption' configures a RuleEngine while creating it with 'New'
+type EngineOption func(*engineOption)
+
+// 'WithOperatorRegistry' sets operator registry for a RuleEngine, engine supports operators registered with the registry.
+// By default, RuleEngine supports built-in operators only.
+func WithOperatorRegistry(registry *OperatorRegistry) EngineOption {
+	return func(op *engineOption) {
+		if registry != nil {
+			op.registry = registry
+		}
+	}
+}
+
+// 'WithGlobalOperatorRegistry' sets global registry for a RuleEngine, engine supports built-in operators and operators
+// registered with deprecated 'RegisterOperator' by any package within the process.
+//
+// Deprecated: use 'WithOperatorRegistry' having operators registered with the registry instead.
+func WithGlobalOperatorRegistry() EngineOption {
+	return func(op *engineOption) {
+		op.registry = globalRegistry
+	}
+}
+
+// 'WithDateTimeLayout' sets layout (see time.Layout) to parse input values and default values (see 'FieldOption') of
+// DateTime fields, default is time.RFC3339. DateTime constant operands are always parsed as RFC3339.
+func WithDateTimeLayout(layout string) EngineOption {
+	return func(op *engineOption) {
+		if len(layout) != 0 {
+			op.dateTimeLayout = layout
+		}
+	}
+}
+
+// 'WithRuleIndex' enables rule index, rules are indexed by field values expected by their equality ('==') or 'in'
+// conditions on field and constant operands, which are either root condition or a sub condition of root 'and'
+// condition. Rules without such conditions are indexed by range of Integer or Float field expected by their '>', '>=',
+// '<', '<=' conditions. Evaluation considers only rules expecting input field values along with rules which are not
+// indexed, output is same as evaluation without index. Indexed evaluation is sequential, hence 'WithParallel'
+// evaluation option is not considered, evaluation having trace considers all rules.
+func WithRuleIndex() EngineOption {
+	return func(op *engineOption) {
+		op.ruleIndex = true
+	}
+}
+
+// 'TieBreakPolicy' defines order of rules having same priority, which is considered by priority based evaluation and
+// output order
+type TieBreakPolicy uint
+
+const (
+	// rules having same priority are ordered by rule name
+	TieBreakByName TieBreakPolicy = iota + 1
+
+	// rules having same priority are ordered as declared in JSON configuration, rules which are not declared in JSON
+	// (ex. configuration built in code) are ordered after declared rules by rule name. Rule added to an engine with
+	// 'AddRule' is ordered after existing rules, rule replaced with 'ReplaceRule' keeps its order.
+	TieBreakByDeclarationOrder
+
+	// rules having same priority are not allowed, creating or updating engine fails with ErrCodeDuplicatePriority
+	TieBreakError
+)
+
+// 'WithTieBreak' sets policy to order rules having same priority, default is TieBreakByName.
+func WithTieBreak(policy TieBreakPolicy) EngineOption {
+	return func(op *engineOption) {
+		if policy >= TieBreakByName && policy <= TieBreakError {
+			op.tieBreak = policy
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package ruleenginecore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// reports whether rule 'a' is ordered before rule 'b', rules are ordered by ascending priority and then as per policy
+func (policy TieBreakPolicy) less(a, b *rule) bool {
+	if a.priority != b.priority {
+		return a.priority < b.priority
+	}
+	if policy == TieBreakByDeclarationOrder && a.order != b.order {
+		return a.order < b.order
+	}
+	return a.name < b.name
+}
+
+// returns declaration order of the rules, rules declared in JSON configuration are ordered as declared, followed by
+// other rules in name order
+func ruleDeclarationOrder(config *RuleEngineConfig) map[string]int {
+	ret := make(map[string]int, len(config.Rules))
+	for _, ruleName := range config.ruleOrder {
+		if _, ok := config.Rules[ruleName]; !ok {
+			continue
+		}
+		if _, ok := ret[ruleName]; !ok {
+			ret[ruleName] = len(ret)
+		}
+	}
+
+	for _, ruleName := range sortedKeys(config.Rules) {
+		if _, ok := ret[ruleName]; !ok {
+			ret[ruleName] = len(ret)
+		}
+	}
+	return ret
+}
+
+// rules having same priority
+type priorityCollision struct {
+	priority  int
+	ruleNames []string
+}
+
+// returns collisions in ascending priority order, rule names of a collision are ordered by name
+func priorityCollisions(rules map[string]*RuleConfig) []*priorityCollision {
+	collisions := map[int]*priorityCollision{}
+	for _, ruleName := range sortedKeys(rules) {
+		if rules[ruleName] == nil {
+			continue
+		}
+		priority := rules[ruleName].Priority
+		if _, ok := collisions[priority]; !ok {
+			collisions[priority] = &priorityCollision{priority: priority}
+		}
+		collisions[priority].ruleNames = append(collisions[priority].ruleNames, ruleName)
+	}
+
+	ret := []*priorityCollision{}
+	for _, collision := range collisions {
+		if len(collision.ruleNames) > 1 {
+			ret = append(ret, collision)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].priority < ret[j].priority
+	})
+	return ret
+}
+
+func (collision *priorityCollision) String() string {
+	return fmt.Sprintf("rules: %v have priority: %v", strings.Join(collision.ruleNames, ","), collision.priority)
+}
+
+// returns validation error for every rule of the collisions
+func priorityCollisionErrors(collisions []*priorityCollision) []*ValidationError {
+	ret := []*ValidationError{}
+	for _, collision := range collisions {
+		for _, ruleName := range collision.ruleNames {
+			err := newError(ErrCodeDuplicatePriority, collision.String()).withRule(ruleName)
+			ret = append(ret, newValidationError(err, configPath("rules", ruleName, "priority")))
+		}
+	}
+	return ret
+}
@@ -0,0 +1,161 @@
+package ruleenginecore
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const testTieBreakConfig = `{
+	"fields": {"amount": "int"},
+	"conditionTypes": {
+		"amountMoreThan100": {"operator": ">", "operands": [
+			{"type": "field", "valuetype": "int", "value": "amount"},
+			{"type": "constant", "valuetype": "int", "value": "100"}
+		]}
+	},
+	"rules": {
+		"zRule": {"priority": 1, "condition": {"type": "amountMoreThan100"}},
+		"lowRule": {"priority": 2, "condition": {"type": "amountMoreThan100"}},
+		"aRule": {"priority": 1, "condition": {"type": "amountMoreThan100"}},
+		"mRule": {"priority": 1, "condition": {"type": "amountMoreThan100"}}
+	}
+}`
+
+func TestRuleEngine_TieBreak(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []EngineOption
+		update    func(UpdatableRuleEngine) (UpdatableRuleEngine, error)
+		wantRules []string
+		wantErr   *RuleEngineError
+	}{
+		{
+			name:      "ByName",
+			wantRules: []string{"aRule", "mRule", "zRule", "lowRule"},
+		},
+		{
+			name:      "ByDeclarationOrder",
+			opts:      []EngineOption{WithTieBreak(TieBreakByDeclarationOrder)},
+			wantRules: []string{"zRule", "aRule", "mRule", "lowRule"},
+		},
+		{
+			name: "ByName_AddRule",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.AddRule("bRule", &RuleConfig{Priority: 1, RootCondition: &Condition{Type: "amountMoreThan100"}})
+			},
+			wantRules: []string{"aRule", "bRule", "mRule", "zRule", "lowRule"},
+		},
+		{
+			name: "ByDeclarationOrder_AddRule",
+			opts: []EngineOption{WithTieBreak(TieBreakByDeclarationOrder)},
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.AddRule("bRule", &RuleConfig{Priority: 1, RootCondition: &Condition{Type: "amountMoreThan100"}})
+			},
+			wantRules: []string{"zRule", "aRule", "mRule", "bRule", "lowRule"},
+		},
+		{
+			name: "ByDeclarationOrder_ReplaceRule",
+			opts: []EngineOption{WithTieBreak(TieBreakByDeclarationOrder)},
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.ReplaceRule("zRule", &RuleConfig{Priority: 1, RootCondition: &Condition{Type: "amountMoreThan100"}})
+			},
+			wantRules: []string{"zRule", "aRule", "mRule", "lowRule"},
+		},
+		{
+			name:    "Error",
+			opts:    []EngineOption{WithTieBreak(TieBreakError)},
+			wantErr: newError(ErrCodeDuplicatePriority),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &RuleEngineConfig{}
+			if err := json.Unmarshal([]byte(testTieBreakConfig), config); err != nil {
+				t.Fatalf("json.Unmarshal() err %v", err)
+			}
+
+			engine, gotErr := NewUpdatable(config, tt.opts...)
+			if gotErr == nil && tt.update != nil {
+				engine, gotErr = tt.update(engine)
+			}
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Fatalf("gotErr %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			// order of rules having same priority does not change across engines
+			for i := 0; i < 5; i++ {
+				if gotRules := testMatchedRules(t, engine, Input{"amount": "150"}); !reflect.DeepEqual(gotRules, tt.wantRules) {
+					t.Fatalf("matched %v, want %v", gotRules, tt.wantRules)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleEngine_TieBreakError_Update(t *testing.T) {
+	engine, err := NewUpdatable(testUpdateConfig(), WithTieBreak(TieBreakError))
+	if err != nil {
+		t.Fatalf("NewUpdatable() err %v", err)
+	}
+
+	ruleConfig := &RuleConfig{Priority: 1, RootCondition: &Condition{Type: "cityDelhi"}}
+	if _, gotErr := engine.AddRule("newRule", ruleConfig); !errors.Is(gotErr, ErrDuplicatePriority) {
+		t.Errorf("AddRule() gotErr %v, wantErr %v", gotErr, ErrDuplicatePriority)
+	}
+	if _, gotErr := engine.ReplaceRule("cityRule", ruleConfig); !errors.Is(gotErr, ErrDuplicatePriority) {
+		t.Errorf("ReplaceRule() gotErr %v, wantErr %v", gotErr, ErrDuplicatePriority)
+	}
+	if _, gotErr := engine.ReplaceRule("amountRule", ruleConfig); gotErr != nil {
+		t.Errorf("ReplaceRule() gotErr %v", gotErr)
+	}
+}
+
+func TestRuleEngine_TieBreakError_New(t *testing.T) {
+	config := &RuleEngineConfig{}
+	if err := json.Unmarshal([]byte(testTieBreakConfig), config); err != nil {
+		t.Fatalf("json.Unmarshal() err %v", err)
+	}
+	config.Rules["highRule"] = &RuleConfig{Priority: 3, RootCondition: &Condition{Type: "amountMoreThan100"}}
+	config.Rules["otherHighRule"] = &RuleConfig{Priority: 3, RootCondition: &Condition{Type: "amountMoreThan100"}}
+
+	_, gotErr := New(config, WithTieBreak(TieBreakError))
+	var ruleEngineErr *RuleEngineError
+	if !errors.As(gotErr, &ruleEngineErr) || ruleEngineErr.ErrCode != ErrCodeDuplicatePriority {
+		t.Fatalf("New() gotErr %v, wantErr %v", gotErr, ErrDuplicatePriority)
+	}
+	if ruleEngineErr.RuleName != "aRule" {
+		t.Errorf("New() got RuleName %v, want aRule", ruleEngineErr.RuleName)
+	}
+	wantMsg := "rules: aRule,mRule,zRule have priority: 1, rules: highRule,otherHighRule have priority: 3"
+	if ruleEngineErr.OtherMsg != wantMsg {
+		t.Errorf("New() got OtherMsg %v, want %v", ruleEngineErr.OtherMsg, wantMsg)
+	}
+}
+
+func TestValidate_PriorityCollision(t *testing.T) {
+	config := &RuleEngineConfig{}
+	if err := json.Unmarshal([]byte(testTieBreakConfig), config); err != nil {
+		t.Fatalf("json.Unmarshal() err %v", err)
+	}
+
+	if got := Validate(config); len(got) != 0 {
+		t.Errorf("Validate() got %v, want none", got)
+	}
+
+	gotPaths := []string{}
+	for _, problem := range Validate(config, WithTieBreak(TieBreakError)) {
+		if !errors.Is(problem, ErrDuplicatePriority) {
+			t.Errorf("Validate() got %v, want %v", problem, ErrDuplicatePriority)
+		}
+		gotPaths = append(gotPaths, problem.Path)
+	}
+	wantPaths := []string{`rules["aRule"].priority`, `rules["mRule"].priority`, `rules["zRule"].priority`}
+	if !reflect.DeepEqual(gotPaths, wantPaths) {
+		t.Errorf("Validate() got %v, want %v", gotPaths, wantPaths)
+	}
+}
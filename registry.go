@@ -0,0 +1,144 @@
+package ruleenginecore
+
+import (
+	"fmt"
+)
+
+// 'OperatorSpec' defines a custom operator, which can be used as 'Operator' while defining ConditionType
+//
+// all operands of a ConditionType using custom operator are expected to have same valueType, as operand values are
+// passed to 'Evaluate' in typed form (bool for Boolean, string for String, int64 for Integer, float64 for Float,
+// time.Time for DateTime, time.Duration for Duration and []any having typed elements for list valueTypes)
+type OperatorSpec struct {
+	// 'OperandCount' defines number of operands expected by the operator
+	OperandCount int
+
+	// 'ValueTypes' defines supported operand valueTypes
+	ValueTypes []ValueType
+
+	// 'Evaluate' evaluates the operator for operand values, values are in same order as ConditionType operands
+	Evaluate func(values []any) bool
+}
+
+func (spec *OperatorSpec) validate(operator string) *RuleEngineError {
+	if len(operator) == 0 {
+		return newError(ErrCodeInvalidOperatorSpec, "Operator name is empty")
+	}
+
+	if spec.OperandCount < 1 {
+		return newError(ErrCodeInvalidOperatorSpec,
+			fmt.Sprintf("Operator: %v, expecting at least one operand", operator))
+	}
+
+	if len(spec.ValueTypes) == 0 {
+		return newError(ErrCodeInvalidOperatorSpec,
+			fmt.Sprintf("Operator: %v, expecting at least one valueType", operator))
+	}
+
+	for _, valueType := range spec.ValueTypes {
+		if !valueType.isValid() {
+			return newError(ErrCodeInvalidOperatorSpec,
+				fmt.Sprintf("Operator: %v, invalid valueType: %v. Supported value types %v", operator, valueType, valueTypeList))
+		}
+	}
+
+	if spec.Evaluate == nil {
+		return newError(ErrCodeInvalidOperatorSpec,
+			fmt.Sprintf("Operator: %v, Evaluate func is not defined", operator))
+	}
+
+	return nil
+}
+
+// 'OperatorRegistry' maintains operators and respective ConditionType validations available for a RuleEngine, see
+// 'RegisterOperator' and 'RegisterConditionTypeValidator'
+//
+// Every RuleEngine uses its own registry (see 'WithOperatorRegistry'), so custom operators registered with one
+// registry are not visible to engines created with other registries.
+type OperatorRegistry struct {
+	validator   *ruleEngineConfigValidator
+	evalFactory *evaluatorFactory
+}
+
+// 'NewOperatorRegistry' creates a registry having built-in operators, see 'ConditionType' for built-in operators
+func NewOperatorRegistry() *OperatorRegistry {
+	registry := &OperatorRegistry{
+		validator:   newRuleEngineConfigValidator(),
+		evalFactory: newEvaluatorFactory(),
+	}
+	registry.validator.operatorExist = registry.evalFactory.exist
+	return registry
+}
+
+// 'RegisterOperator' registers custom operator with given name, once registered operator can be used with ConditionType
+// for the engines created with this registry.
+//
+// registration is expected to happen before creating a RuleEngine, it is not safe to register operator concurrently
+// with 'New' using the same registry. Registering already existing operator results into an error.
+func (r *OperatorRegistry) RegisterOperator(operator string, spec OperatorSpec) error {
+	if err := spec.validate(operator); err != nil {
+		return err
+	}
+
+	if r.evalFactory.exist(operator) {
+		return newError(ErrCodeInvalidOperatorSpec, fmt.Sprintf("Operator: %v is already registered", operator))
+	}
+
+	r.validator.addConditionTypeValidator(operator,
+		operandCountValidator(spec.OperandCount),
+		operandsWithSameValueTypeValidator(),
+		operandValueTypeValidator(spec.ValueTypes...),
+		operandValidator(),
+	)
+
+	evalFunc := spec.Evaluate
+	r.evalFactory.AddEvaluator(operator, func(operands []*Operand) evaluator {
+		return &customOperatorEvaluator{operands: operands, evalFunc: evalFunc}
+	})
+
+	return nil
+}
+
+// 'RegisterConditionTypeValidator' registers additional validation for condition types using given operator, it is
+// applied by the engines created with this registry after built-in validations of the operator, hence operand values
+// are already parsed. Condition type is invalid if 'validate' returns an error, which is reported as the cause of
+// ErrCodeInvalidConditionType error.
+//
+// registration is expected to happen before creating a RuleEngine, same as 'RegisterOperator'. Registering validation
+// for an unknown operator results into an error.
+func (r *OperatorRegistry) RegisterConditionTypeValidator(operator string, validate func(ct *ConditionType) error) error {
+	if !r.evalFactory.exist(operator) {
+		return newError(ErrCodeInvalidOperator, fmt.Sprintf("Operator: %v is not registered", operator))
+	}
+
+	if validate == nil {
+		return newError(ErrCodeInvalidOperatorSpec,
+			fmt.Sprintf("Operator: %v, validate func is not defined", operator))
+	}
+
+	r.validator.appendConditionTypeValidator(operator, func(ct *ConditionType, fs Fields) *RuleEngineError {
+		if err := validate(ct); err != nil {
+			return newError(ErrCodeInvalidConditionType, fmt.Sprintf("Operator: %v", operator)).wrap(err)
+		}
+		return nil
+	})
+	return nil
+}
+
+// 'RegisterOperator' registers custom operator with the global registry, which is used only by engines created with
+// 'WithGlobalOperatorRegistry' option. Hence the operator is visible to all such engines within the process, engines
+// created without a registry option support built-in operators only.
+//
+// Deprecated: create a registry with 'NewOperatorRegistry' and register operators with 'OperatorRegistry.RegisterOperator'
+// instead, engines use the registry with 'WithOperatorRegistry' option.
+func RegisterOperator(operator string, spec OperatorSpec) error {
+	return globalRegistry.RegisterOperator(operator, spec)
+}
+
+// registry used by engines created without a registry option, having built-in operators only. Nothing is registered
+// with it, hence such engines are not affected by registrations made elsewhere within the process.
+var defaultRegistry = NewOperatorRegistry()
+
+// registry used by engines created with 'WithGlobalOperatorRegistry' option, having built-in operators and operators
+// registered with deprecated 'RegisterOperator'
+var globalRegistry = NewOperatorRegistry()
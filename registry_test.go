@@ -0,0 +1,322 @@
+package ruleenginecore
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOperatorRegistry_RegisterOperator(t *testing.T) {
+	validEvaluate := func(values []any) bool { return true }
+	type args struct {
+		operator string
+		spec     OperatorSpec
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr *RuleEngineError
+	}{
+		{
+			name: "valid",
+			args: args{
+				operator: "testRegisterOperator_valid",
+				spec: OperatorSpec{
+					OperandCount: 2,
+					ValueTypes:   []ValueType{String},
+					Evaluate:     validEvaluate,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid_AlreadyRegistered",
+			args: args{
+				operator: EqualOperator,
+				spec: OperatorSpec{
+					OperandCount: 2,
+					ValueTypes:   []ValueType{String},
+					Evaluate:     validEvaluate,
+				},
+			},
+			wantErr: newError(ErrCodeInvalidOperatorSpec),
+		},
+		{
+			name: "invalid_EmptyName",
+			args: args{
+				operator: "",
+				spec: OperatorSpec{
+					OperandCount: 2,
+					ValueTypes:   []ValueType{String},
+					Evaluate:     validEvaluate,
+				},
+			},
+			wantErr: newError(ErrCodeInvalidOperatorSpec),
+		},
+		{
+			name: "invalid_OperandCount",
+			args: args{
+				operator: "testRegisterOperator_invalidOperandCount",
+				spec: OperatorSpec{
+					OperandCount: 0,
+					ValueTypes:   []ValueType{String},
+					Evaluate:     validEvaluate,
+				},
+			},
+			wantErr: newError(ErrCodeInvalidOperatorSpec),
+		},
+		{
+			name: "invalid_ValueType",
+			args: args{
+				operator: "testRegisterOperator_invalidValueType",
+				spec: OperatorSpec{
+					OperandCount: 2,
+					ValueTypes:   []ValueType{unknownValueType},
+					Evaluate:     validEvaluate,
+				},
+			},
+			wantErr: newError(ErrCodeInvalidOperatorSpec),
+		},
+		{
+			name: "invalid_MissingEvaluate",
+			args: args{
+				operator: "testRegisterOperator_missingEvaluate",
+				spec: OperatorSpec{
+					OperandCount: 2,
+					ValueTypes:   []ValueType{String},
+				},
+			},
+			wantErr: newError(ErrCodeInvalidOperatorSpec),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewOperatorRegistry()
+			if gotErr := registry.RegisterOperator(tt.args.operator, tt.args.spec); !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("OperatorRegistry.RegisterOperator() gotErr %v, wantErr %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func testStartsWithConfig(startsWith string) *RuleEngineConfig {
+	return &RuleEngineConfig{
+		Fields: Fields{
+			"couponCode": String,
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"festiveCoupon": {
+				Operator: startsWith,
+				Operands: []*Operand{
+					{
+						Type:      Field,
+						ValueType: String,
+						Val:       "couponCode",
+					},
+					{
+						Type:      Constant,
+						ValueType: String,
+						Val:       "FEST",
+					},
+				},
+			},
+		},
+		Rules: map[string]*RuleConfig{
+			"festiveDiscount": {
+				Priority: 1,
+				RootCondition: &Condition{
+					Type: "festiveCoupon",
+				},
+			},
+		},
+	}
+}
+
+func TestOperatorRegistry_Evaluate(t *testing.T) {
+	startsWith := "startsWith"
+	registry := NewOperatorRegistry()
+	err := registry.RegisterOperator(startsWith, OperatorSpec{
+		OperandCount: 2,
+		ValueTypes:   []ValueType{String},
+		Evaluate: func(values []any) bool {
+			return strings.HasPrefix(values[0].(string), values[1].(string))
+		},
+	})
+	if err != nil {
+		t.Fatalf("OperatorRegistry.RegisterOperator() gotErr %v", err)
+	}
+
+	engine, err := New(testStartsWithConfig(startsWith), WithOperatorRegistry(registry))
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input Input
+		want  bool
+	}{
+		{
+			name:  "matched",
+			input: Input{"couponCode": "FEST2023"},
+			want:  true,
+		},
+		{
+			name:  "notMatched",
+			input: Input{"couponCode": "NEWYEAR2023"},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := engine.EvaluateSingleRule(context.TODO(), tt.input, "festiveDiscount")
+			if gotErr != nil {
+				t.Errorf("EvaluateSingleRule() gotErr %v", gotErr)
+			}
+			if (got != nil) != tt.want {
+				t.Errorf("EvaluateSingleRule() got %v, want matched %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperatorRegistry_Isolation(t *testing.T) {
+	startsWith := "startsWith"
+	registry := NewOperatorRegistry()
+	if err := registry.RegisterOperator(startsWith, OperatorSpec{
+		OperandCount: 2,
+		ValueTypes:   []ValueType{String},
+		Evaluate:     func(values []any) bool { return true },
+	}); err != nil {
+		t.Fatalf("OperatorRegistry.RegisterOperator() gotErr %v", err)
+	}
+
+	// engine with default registry does not know about operator registered with other registry
+	_, err := New(testStartsWithConfig(startsWith))
+	if !isErrorEqual(err, newError(ErrCodeInvalidOperator)) {
+		t.Errorf("New() gotErr %v, wantErr %v", err, newError(ErrCodeInvalidOperator))
+	}
+
+	// same operator can be registered with other registry
+	if err := NewOperatorRegistry().RegisterOperator(startsWith, OperatorSpec{
+		OperandCount: 2,
+		ValueTypes:   []ValueType{String},
+		Evaluate:     func(values []any) bool { return false },
+	}); err != nil {
+		t.Errorf("OperatorRegistry.RegisterOperator() gotErr %v", err)
+	}
+}
+
+func TestOperatorRegistry_InvalidConditionType(t *testing.T) {
+	operator := "positive"
+	registry := NewOperatorRegistry()
+	if err := registry.RegisterOperator(operator, OperatorSpec{
+		OperandCount: 1,
+		ValueTypes:   []ValueType{Integer},
+		Evaluate:     func(values []any) bool { return values[0].(int64) > 0 },
+	}); err != nil {
+		t.Fatalf("OperatorRegistry.RegisterOperator() gotErr %v", err)
+	}
+
+	_, err := New(&RuleEngineConfig{
+		Fields: Fields{
+			"name": String,
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"invalid": {
+				Operator: operator,
+				Operands: []*Operand{
+					{
+						Type:      Field,
+						ValueType: String,
+						Val:       "name",
+					},
+				},
+			},
+		},
+	}, WithOperatorRegistry(registry))
+	if !isErrorEqual(err, newError(ErrCodeInvalidOperand)) {
+		t.Errorf("New() gotErr %v, wantErr %v", err, newError(ErrCodeInvalidOperand))
+	}
+}
+
+func TestOperatorRegistry_RegisterConditionTypeValidator(t *testing.T) {
+	errPrefix := errors.New("coupon code is expected to have upper case prefix")
+	validate := func(ct *ConditionType) error {
+		for _, operand := range ct.Operands {
+			if !operand.isField() && strings.ToUpper(operand.Val) != operand.Val {
+				return errPrefix
+			}
+		}
+		return nil
+	}
+
+	registry := NewOperatorRegistry()
+	if err := registry.RegisterOperator("startsWith", OperatorSpec{
+		OperandCount: 2,
+		ValueTypes:   []ValueType{String},
+		Evaluate:     func(values []any) bool { return strings.HasPrefix(values[0].(string), values[1].(string)) },
+	}); err != nil {
+		t.Fatalf("OperatorRegistry.RegisterOperator() gotErr %v", err)
+	}
+	for _, operator := range []string{"startsWith", EqualOperator} {
+		if err := registry.RegisterConditionTypeValidator(operator, validate); err != nil {
+			t.Fatalf("OperatorRegistry.RegisterConditionTypeValidator() gotErr %v", err)
+		}
+	}
+
+	if err := registry.RegisterConditionTypeValidator("unknown", validate); !isErrorEqual(err, newError(ErrCodeInvalidOperator)) {
+		t.Errorf("OperatorRegistry.RegisterConditionTypeValidator() gotErr %v, wantErr %v", err, newError(ErrCodeInvalidOperator))
+	}
+	if err := registry.RegisterConditionTypeValidator(EqualOperator, nil); !isErrorEqual(err, newError(ErrCodeInvalidOperatorSpec)) {
+		t.Errorf("OperatorRegistry.RegisterConditionTypeValidator() gotErr %v, wantErr %v", err, newError(ErrCodeInvalidOperatorSpec))
+	}
+
+	if _, err := New(testStartsWithConfig("startsWith"), WithOperatorRegistry(registry)); err != nil {
+		t.Errorf("New() gotErr %v", err)
+	}
+
+	config := testStartsWithConfig("startsWith")
+	config.ConditionTypes["festiveCoupon"].Operands[1].Val = "fest"
+	_, err := New(config, WithOperatorRegistry(registry))
+	if !isErrorEqual(err, newError(ErrCodeInvalidConditionType)) || !errors.Is(err, errPrefix) {
+		t.Errorf("New() gotErr %v, wantErr %v", err, errPrefix)
+	}
+
+	// built-in operator validation is extended only for engines created with the registry
+	config = testStartsWithConfig(EqualOperator)
+	config.ConditionTypes["festiveCoupon"].Operands[1].Val = "fest"
+	if _, err := New(config, WithOperatorRegistry(registry)); !errors.Is(err, errPrefix) {
+		t.Errorf("New() gotErr %v, wantErr %v", err, errPrefix)
+	}
+	if _, err := New(config); err != nil {
+		t.Errorf("New() gotErr %v", err)
+	}
+}
+
+func TestRegisterOperator(t *testing.T) {
+	operator := "testRegisterOperator_globalRegistry"
+	if err := RegisterOperator(operator, OperatorSpec{
+		OperandCount: 2,
+		ValueTypes:   []ValueType{String},
+		Evaluate:     func(values []any) bool { return strings.HasPrefix(values[0].(string), values[1].(string)) },
+	}); err != nil {
+		t.Fatalf("RegisterOperator() gotErr %v", err)
+	}
+
+	// operator registered with the global registry is visible to engines created with the global registry only
+	engine, err := New(testStartsWithConfig(operator), WithGlobalOperatorRegistry())
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+	if got, _ := engine.EvaluateSingleRule(context.TODO(), Input{"couponCode": "FEST2023"}, "festiveDiscount"); got == nil {
+		t.Errorf("EvaluateSingleRule() got %v, want matched", got)
+	}
+
+	for _, opts := range [][]EngineOption{{}, {WithOperatorRegistry(NewOperatorRegistry())}} {
+		if _, err := New(testStartsWithConfig(operator), opts...); !isErrorEqual(err, newError(ErrCodeInvalidOperator)) {
+			t.Errorf("New() gotErr %v, wantErr %v", err, newError(ErrCodeInvalidOperator))
+		}
+	}
+}
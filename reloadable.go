@@ -0,0 +1,181 @@
+package ruleenginecore
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// 'ReloadableEngine' is a RuleEngine, whose configuration can be reloaded while evaluations are in progress.
+//
+// Reload validates and builds a new engine with the configuration, then atomically replaces the current engine.
+// Evaluations in progress complete with the engine they started with. Every successful reload increments version,
+// which is set on every Output.
+type ReloadableEngine struct {
+	current atomic.Pointer[versionedEngine]
+
+	// options to create every engine
+	opts []EngineOption
+
+	// serializes reloads
+	mu sync.Mutex
+}
+
+type versionedEngine struct {
+	engine  UpdatableRuleEngine
+	version uint64
+}
+
+// creates new reloadable rule engine using provided configuration and options, initial version is 1
+func NewReloadable(engineConfig *RuleEngineConfig, opts ...EngineOption) (*ReloadableEngine, error) {
+	engine, err := NewUpdatable(engineConfig, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	re := &ReloadableEngine{opts: opts}
+	re.current.Store(&versionedEngine{engine: engine, version: 1})
+	return re, nil
+}
+
+// 'Reload' replaces current engine with new engine built using provided configuration. In case of an error, current
+// engine is kept as is.
+func (re *ReloadableEngine) Reload(engineConfig *RuleEngineConfig) error {
+	re.mu.Lock()
+	defer re.mu.Unlock()
+
+	engine, err := NewUpdatable(engineConfig, re.opts...)
+	if err != nil {
+		return err
+	}
+
+	re.current.Store(&versionedEngine{engine: engine, version: re.current.Load().version + 1})
+	return nil
+}
+
+// 'WatchSource' reloads the engine with every configuration change of the source until ctx is done. 'onReload' is
+// called after every change with an error if loading or reloading failed, it is optional. WatchSource blocks, hence
+// it is expected to be called on a separate goroutine.
+func (re *ReloadableEngine) WatchSource(ctx context.Context, source ConfigSource, onReload func(err error)) {
+	for update := range source.Watch(ctx) {
+		err := update.Err
+		if err == nil {
+			err = re.Reload(update.Config)
+		}
+
+		if onReload != nil {
+			onReload(err)
+		}
+	}
+}
+
+// 'Version' returns version of current engine
+func (re *ReloadableEngine) Version() uint64 {
+	return re.current.Load().version
+}
+
+func (re *ReloadableEngine) Evaluate(ctx context.Context, input Input, op *evaluateOption) ([]*Output, error) {
+	current := re.current.Load()
+	return current.versioned(current.engine.Evaluate(ctx, input, op))
+}
+
+func (re *ReloadableEngine) EvaluateSingleRule(ctx context.Context, input Input, rulename string) (*Output, error) {
+	current := re.current.Load()
+	return current.versionedSingle(current.engine.EvaluateSingleRule(ctx, input, rulename))
+}
+
+func (re *ReloadableEngine) EvaluateTyped(ctx context.Context, input TypedInput, op *evaluateOption) ([]*Output, error) {
+	current := re.current.Load()
+	return current.versioned(current.engine.EvaluateTyped(ctx, input, op))
+}
+
+func (re *ReloadableEngine) EvaluateSingleRuleTyped(ctx context.Context, input TypedInput, rulename string) (*Output, error) {
+	current := re.current.Load()
+	return current.versionedSingle(current.engine.EvaluateSingleRuleTyped(ctx, input, rulename))
+}
+
+func (re *ReloadableEngine) EvaluateStruct(ctx context.Context, input any, op *evaluateOption) ([]*Output, error) {
+	current := re.current.Load()
+	return current.versioned(current.engine.EvaluateStruct(ctx, input, op))
+}
+
+func (re *ReloadableEngine) EvaluateSingleRuleStruct(ctx context.Context, input any, rulename string) (*Output, error) {
+	current := re.current.Load()
+	return current.versionedSingle(current.engine.EvaluateSingleRuleStruct(ctx, input, rulename))
+}
+
+func (re *ReloadableEngine) EvaluateJSON(ctx context.Context, input []byte, op *evaluateOption) ([]*Output, error) {
+	current := re.current.Load()
+	return current.versioned(current.engine.EvaluateJSON(ctx, input, op))
+}
+
+func (re *ReloadableEngine) EvaluateSingleRuleJSON(ctx context.Context, input []byte, rulename string) (*Output, error) {
+	current := re.current.Load()
+	return current.versionedSingle(current.engine.EvaluateSingleRuleJSON(ctx, input, rulename))
+}
+
+// 'ConditionCacheStats' reports stats of current engine
+func (re *ReloadableEngine) ConditionCacheStats() ConditionCacheStats {
+	return re.current.Load().engine.ConditionCacheStats()
+}
+
+// 'AddRule' adds the rule to current engine and replaces current engine, same as reload. In case of an error, current
+// engine is kept as is.
+func (re *ReloadableEngine) AddRule(ruleName string, ruleConfig *RuleConfig) error {
+	return re.update(func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+		return engine.AddRule(ruleName, ruleConfig)
+	})
+}
+
+// 'ReplaceRule' replaces the rule of current engine and replaces current engine, same as reload. In case of an error,
+// current engine is kept as is.
+func (re *ReloadableEngine) ReplaceRule(ruleName string, ruleConfig *RuleConfig) error {
+	return re.update(func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+		return engine.ReplaceRule(ruleName, ruleConfig)
+	})
+}
+
+// 'RemoveRule' removes the rule from current engine and replaces current engine, same as reload. In case of an error,
+// current engine is kept as is.
+func (re *ReloadableEngine) RemoveRule(ruleName string) error {
+	return re.update(func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+		return engine.RemoveRule(ruleName)
+	})
+}
+
+// 'SetConditionType' sets the condition type of current engine and replaces current engine, same as reload. In case of
+// an error, current engine is kept as is.
+func (re *ReloadableEngine) SetConditionType(conditionTypeName string, conditionType *ConditionType) error {
+	return re.update(func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+		return engine.SetConditionType(conditionTypeName, conditionType)
+	})
+}
+
+// replaces current engine with the engine updated from current engine
+func (re *ReloadableEngine) update(updateFunc func(UpdatableRuleEngine) (UpdatableRuleEngine, error)) error {
+	re.mu.Lock()
+	defer re.mu.Unlock()
+
+	current := re.current.Load()
+	engine, err := updateFunc(current.engine)
+	if err != nil {
+		return err
+	}
+
+	re.current.Store(&versionedEngine{engine: engine, version: current.version + 1})
+	return nil
+}
+
+func (ve *versionedEngine) versioned(outputs []*Output, err error) ([]*Output, error) {
+	for _, output := range outputs {
+		output.Version = ve.version
+	}
+	return outputs, err
+}
+
+func (ve *versionedEngine) versionedSingle(output *Output, err error) (*Output, error) {
+	if output != nil {
+		output.Version = ve.version
+	}
+	return output, err
+}
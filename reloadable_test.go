@@ -0,0 +1,139 @@
+package ruleenginecore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+var _ RuleEngine = (*ReloadableEngine)(nil)
+
+// returns config having single rule matching amount more than given threshold
+func testThresholdConfig(ruleName string, threshold string) *RuleEngineConfig {
+	return &RuleEngineConfig{
+		Fields: Fields{"amount": Integer},
+		ConditionTypes: map[string]*ConditionType{
+			"amountMoreThan": {Operator: GreaterOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Integer, Val: "amount"},
+				{Type: Constant, ValueType: Integer, Val: threshold},
+			}},
+		},
+		Rules: map[string]*RuleConfig{
+			ruleName: {Priority: 1, RootCondition: &Condition{Type: "amountMoreThan"}},
+		},
+	}
+}
+
+func TestReloadableEngine(t *testing.T) {
+	engine, err := NewReloadable(testThresholdConfig("big", "100"))
+	if err != nil {
+		t.Fatalf("NewReloadable() gotErr %v", err)
+	}
+
+	input := Input{"amount": "50"}
+	got, gotErr := engine.Evaluate(context.TODO(), input, EvaluateOptions().Complete())
+	if gotErr != nil || len(got) != 0 {
+		t.Fatalf("Evaluate() got %v, gotErr %v, want no output", got, gotErr)
+	}
+
+	if err := engine.Reload(testThresholdConfig("medium", "10")); err != nil {
+		t.Fatalf("Reload() gotErr %v", err)
+	}
+	got, gotErr = engine.Evaluate(context.TODO(), input, EvaluateOptions().Complete())
+	if gotErr != nil || len(got) != 1 || got[0].Rulename != "medium" || got[0].Version != 2 {
+		t.Errorf("Evaluate() got %v, gotErr %v, want medium rule of version 2", got, gotErr)
+	}
+
+	// failed reload keeps current engine
+	if err := engine.Reload(testThresholdConfig("invalid", "ten")); !errors.Is(err, ErrParsingFailed) {
+		t.Errorf("Reload() gotErr %v, wantErr %v", err, newError(ErrCodeParsingFailed))
+	}
+	if version := engine.Version(); version != 2 {
+		t.Errorf("Version() = %v, want 2", version)
+	}
+
+	single, gotErr := engine.EvaluateSingleRuleTyped(context.TODO(), TypedInput{"amount": int64(50)}, "medium")
+	if gotErr != nil || single == nil || single.Version != 2 {
+		t.Errorf("EvaluateSingleRuleTyped() got %v, gotErr %v, want medium rule of version 2", single, gotErr)
+	}
+}
+
+func TestReloadableEngine_ConcurrentReload(t *testing.T) {
+	engine, err := NewReloadable(testThresholdConfig("rule", "10"))
+	if err != nil {
+		t.Fatalf("NewReloadable() gotErr %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				got, gotErr := engine.Evaluate(context.TODO(), Input{"amount": "50"}, EvaluateOptions().Complete())
+				if gotErr != nil || len(got) != 1 || got[0].Version == 0 {
+					t.Errorf("Evaluate() got %v, gotErr %v", got, gotErr)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		if err := engine.Reload(testThresholdConfig("rule", "10")); err != nil {
+			t.Errorf("Reload() gotErr %v", err)
+		}
+	}
+	wg.Wait()
+
+	if version := engine.Version(); version != 11 {
+		t.Errorf("Version() = %v, want 11", version)
+	}
+}
+
+func TestReloadableEngine_ReloadSameConfig(t *testing.T) {
+	defaultCity := "Delhi"
+	config := &RuleEngineConfig{
+		Fields:       Fields{"city": String},
+		FieldOptions: map[string]*FieldOption{"city": {Default: &defaultCity}},
+		ConditionTypes: map[string]*ConditionType{
+			"metroCity": {Operator: InOperator, Operands: []*Operand{
+				{Type: Field, ValueType: String, Val: "city"},
+				{Type: Constant, ValueType: String, Val: "Delhi,Mumbai"},
+			}},
+		},
+		Rules: map[string]*RuleConfig{
+			"metro": {Priority: 1, RootCondition: &Condition{Type: "metroCity"}},
+		},
+	}
+	engine, err := NewReloadable(config)
+	if err != nil {
+		t.Fatalf("NewReloadable() gotErr %v", err)
+	}
+
+	// engine is built with a copy of the config
+	if operand := config.ConditionTypes["metroCity"].Operands[1]; operand.typedValue != nil {
+		t.Errorf("NewReloadable() parsed operand of the config %v", operand.typedValue)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				got, gotErr := engine.Evaluate(context.TODO(), Input{}, EvaluateOptions().Complete())
+				if gotErr != nil || len(got) != 1 {
+					t.Errorf("Evaluate() got %v, gotErr %v", got, gotErr)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		if err := engine.Reload(config); err != nil {
+			t.Errorf("Reload() gotErr %v", err)
+		}
+	}
+	wg.Wait()
+}
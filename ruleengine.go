@@ -5,25 +5,103 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type RuleEngine interface {
 	// 'Evaluate' evaluates the input based on options
-	Evaluate(ctx context.Context, input Input, op *evaluateOption) ([]*Output, *RuleEngineError)
+	Evaluate(ctx context.Context, input Input, op *evaluateOption) ([]*Output, error)
 
 	// 'EvaluateSingleRule' evaluates the input for one rule having given 'rulename'
-	EvaluateSingleRule(ctx context.Context, input Input, rulename string) (*Output, *RuleEngineError)
+	EvaluateSingleRule(ctx context.Context, input Input, rulename string) (*Output, error)
+
+	// 'EvaluateTyped' evaluates the typed input based on options, same as 'Evaluate' without parsing string values
+	EvaluateTyped(ctx context.Context, input TypedInput, op *evaluateOption) ([]*Output, error)
+
+	// 'EvaluateSingleRuleTyped' evaluates the typed input for one rule having given 'rulename'
+	EvaluateSingleRuleTyped(ctx context.Context, input TypedInput, rulename string) (*Output, error)
+
+	// 'EvaluateStruct' evaluates the struct (or pointer to struct) input based on options, struct fields are bound to
+	// RuleEngine fields using 'rule' tag (ex. `rule:"totalAmount"`). Fields are expected to be bound same as fields
+	// expected by 'Evaluate', hence fields having FieldOption or not referenced by any rule can be left unbound.
+	EvaluateStruct(ctx context.Context, input any, op *evaluateOption) ([]*Output, error)
+
+	// 'EvaluateSingleRuleStruct' evaluates the struct (or pointer to struct) input for one rule having given 'rulename'
+	EvaluateSingleRuleStruct(ctx context.Context, input any, rulename string) (*Output, error)
+
+	// 'EvaluateJSON' evaluates JSON object input based on options, field names are considered as path to the value within
+	// JSON input, either as dotted path (ex. "user.tier") or JSON pointer (ex. "/user/tier"). Only field values are
+	// extracted from the input.
+	EvaluateJSON(ctx context.Context, input []byte, op *evaluateOption) ([]*Output, error)
+
+	// 'EvaluateSingleRuleJSON' evaluates JSON object input for one rule having given 'rulename'
+	EvaluateSingleRuleJSON(ctx context.Context, input []byte, rulename string) (*Output, error)
+
+	// 'ConditionCacheStats' reports hits and misses of condition outcome cache. Every condition type is evaluated
+	// at most once per evaluation, rules sharing the condition type reuse its outcome.
+	ConditionCacheStats() ConditionCacheStats
+}
+
+type nowContextKey struct{}
+
+// 'ContextWithNow' returns a copy of ctx carrying 'now' as evaluation time, 'Now' operands are evaluated relative to it.
+// Evaluation considers current time if ctx does not carry evaluation time.
+func ContextWithNow(ctx context.Context, now time.Time) context.Context {
+	return context.WithValue(ctx, nowContextKey{}, now)
+}
+
+func nowFromContext(ctx context.Context) time.Time {
+	if now, ok := ctx.Value(nowContextKey{}).(time.Time); ok {
+		return now
+	}
+	return time.Now()
+}
+
+// 'cancellationCheck' checks context cancellation while evaluating conditions, context is checked once in every
+// 'interval' condition nodes
+type cancellationCheck struct {
+	ctx       context.Context
+	interval  int
+	count     int
+	cancelled bool
+}
+
+// reports whether context is cancelled
+func (c *cancellationCheck) check() bool {
+	if c.cancelled {
+		return true
+	}
+	c.count++
+	if c.count < c.interval {
+		return false
+	}
+	c.count = 0
+	c.cancelled = c.ctx.Err() != nil
+	return c.cancelled
 }
 
 type rule struct {
-	name          string
-	priority      int
+	name     string
+	priority int
+
+	// declaration order of the rule, considered for rules having same priority as per TieBreakByDeclarationOrder
+	order int
+
 	rootEvaluator evaluator
 	result        map[string]any
+
+	// rule compiled from 'rootEvaluator', used for evaluation
+	program program
+
+	// condition tree and condition types of the rule, used for tracing
+	rootCondition  *Condition
+	conditionTypes map[string]*ConditionType
 }
 
-func newRule(ruleName string, r *RuleConfig, customConditionType map[string]*ConditionType, fs Fields) (*rule, *RuleEngineError) {
-	rootEvaluator, err := ruleEvaluatorBuild(r.RootCondition, customConditionType, fs)
+func newRule(ruleName string, r *RuleConfig, buildCtx *ruleBuildContext) (*rule, *RuleEngineError) {
+	rootEvaluator, err := ruleEvaluatorBuild(r.RootCondition, buildCtx)
 	if err != nil {
 		return nil, err
 	}
@@ -32,83 +110,236 @@ func newRule(ruleName string, r *RuleConfig, customConditionType map[string]*Con
 		priority:      r.Priority,
 		result:        r.Result,
 		rootEvaluator: rootEvaluator,
+
+		rootCondition:  r.RootCondition,
+		conditionTypes: buildCtx.conditionTypes,
 	}
 	return ru, nil
 }
 
-func (r *rule) evaluate(ctx context.Context, input parsedInput) (bool, *RuleEngineError) {
-	out := make(chan bool)
-	ctxCancelled := false
-
-	go func(ctx context.Context, input parsedInput, resultChan chan<- bool) {
-		select {
-		case <-ctx.Done():
-			ctxCancelled = true
-			resultChan <- false
-		default:
-			resultChan <- r.rootEvaluator.evaluate(input)
-		}
-	}(ctx, input, out)
+// evaluates the rule, context is checked before evaluating the rule and while evaluating conditions if input carries
+// cancellation check
+func (r *rule) evaluate(ctx context.Context, input *slotInput) (bool, *RuleEngineError) {
+	if ctx.Err() != nil {
+		return false, r.cancelledError(ctx)
+	}
 
-	result := <-out
+	result := r.program.run(input)
 
-	if ctxCancelled {
-		return false, newError(ErrCodeContextCancelled,
-			fmt.Sprintf("Context cancelled while evaluating RuleName: %v", r.name))
+	if input.check != nil && input.check.cancelled {
+		return false, r.cancelledError(ctx)
 	}
 	return result, nil
 }
 
+func (r *rule) cancelledError(ctx context.Context) *RuleEngineError {
+	return newError(ErrCodeContextCancelled, "Context cancelled while evaluating rule").withRule(r.name).wrap(ctx.Err())
+}
+
+// evaluates the rule, records rule trace if 'trace' is not nil
+func (r *rule) evaluateWithTrace(ctx context.Context, input *slotInput, trace *Trace) (bool, *RuleEngineError) {
+	if trace == nil {
+		return r.evaluate(ctx, input)
+	}
+
+	if ctx.Err() != nil {
+		return false, r.cancelledError(ctx)
+	}
+	ruleTrace := r.evaluateTrace(input.parsedValues())
+	trace.Rules = append(trace.Rules, ruleTrace)
+	return ruleTrace.Matched, nil
+}
+
 type ruleEngine struct {
 	fields Fields
 
+	// optional behaviour for fields, fields without option are mandatory
+	fieldOptions map[string]*FieldOption
+
+	// count of rules having field as an operand by slot, input is not required to have fields not referenced by any
+	// rule. Nil if every field is required.
+	fieldReferences []int
+
+	// layout for DateTime input values
+	dateTimeLayout string
+
+	// struct bindings for struct input
+	bindings *structBindingCache
+
+	// field paths for JSON input
+	jsonPaths *jsonPathNode
+
+	// slots of fields for compiled rules, input is parsed into slots
+	fieldSlots map[string]int
+	slotFields []slotField
+
+	// count of conditions indexed by compiled rules, outcome of conditions is memoized per evaluation
+	conditionCount int
+	cacheStats     *conditionCacheStats
+
+	// registry, condition types and index of condition type names, used to derive engine with updated rules
+	registry         *OperatorRegistry
+	conditionTypes   map[string]*ConditionType
+	conditionIndexes map[string]int
+
+	// index of rules, evaluation considers only candidate rules if not nil
+	index *ruleIndex
+
 	// map of rulename and rule
 	ruleMap map[string]*rule
 
-	// ascending ordered rules
-	rules []*rule
+	// ascending ordered rules, rules having same priority are ordered as per tie break policy
+	rules    []*rule
+	tieBreak TieBreakPolicy
+
+	// declaration order for the next added rule
+	nextOrder int
+}
+
+// resolves value for a field missing from the input as per field option, default value is considered if defined,
+// nullable field is kept missing as nil value, otherwise it fails as field is mandatory
+func (re *ruleEngine) resolveMissingField(fieldname string, fieldtype ValueType) (any, *RuleEngineError) {
+	if option, ok := re.fieldOptions[fieldname]; ok {
+		if option.Default != nil {
+			return option.typedDefault, nil
+		}
+		if option.Nullable {
+			return nil, nil
+		}
+	}
+	return nil, newError(ErrCodeFieldNotFound,
+		fmt.Sprintf("Expecting input with name: %v and valueType: %v", fieldname, fieldtype)).withField(fieldname)
+}
+
+// resolves values of fields missing from the input, having nil slot. Mandatory field, which is not referenced by any
+// rule, is kept missing as it is never evaluated.
+func (re *ruleEngine) resolveMissingFields(input *slotInput) *RuleEngineError {
+	for slot, field := range re.slotFields {
+		if input.slots[slot] != nil {
+			continue
+		}
+		val, err := re.resolveMissingField(field.name, field.valueType)
+		if err != nil {
+			if !re.isReferenced(slot) {
+				continue
+			}
+			return err
+		}
+		input.slots[slot] = val
+	}
+	return nil
 }
 
-func (re *ruleEngine) validateAndParseInput(input Input) (parsedInput, *RuleEngineError) {
-	ret := parsedInput{}
-	for fieldname, fieldtype := range re.fields {
-		strVal, found := input[fieldname]
+func (re *ruleEngine) validateAndParseInput(input Input) (*slotInput, *RuleEngineError) {
+	ret := re.newSlotInput()
+	for slot, field := range re.slotFields {
+		strVal, found := input[field.name]
 		if !found {
-			return nil, newError(ErrCodeFieldNotFound,
-				fmt.Sprintf("Expecting input with name: %v and valueType: %v", fieldname, fieldtype))
+			continue
 		}
 
-		if val, err := parseValue(strVal, fieldtype); err != nil {
-			err.addMsg(fmt.Sprintf("Input parsing failed for field: %v having type %v", fieldname, fieldtype))
-			return nil, err
-		} else {
-			ret[fieldname] = val
+		val, err := parseValueWithLayout(strVal, field.valueType, re.dateTimeLayout)
+		if err != nil {
+			err.addMsg(fmt.Sprintf("Input parsing failed for type %v", field.valueType))
+			return nil, err.withField(field.name)
+		}
+		ret.slots[slot] = val
+	}
+
+	if err := re.resolveMissingFields(ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (re *ruleEngine) validateTypedInput(input TypedInput) (*slotInput, *RuleEngineError) {
+	ret := re.newSlotInput()
+	for slot, field := range re.slotFields {
+		val, found := input[field.name]
+		if !found || val == nil {
+			continue
 		}
+
+		typedVal, ok := toTypedValue(val, field.valueType)
+		if !ok {
+			return nil, newError(ErrCodeInvalidValueType,
+				fmt.Sprintf("Input for field: %v having type %v, got value of type %T", field.name, field.valueType,
+					val)).withField(field.name)
+		}
+		ret.slots[slot] = typedVal
 	}
 
+	if err := re.resolveMissingFields(ret); err != nil {
+		return nil, err
+	}
 	return ret, nil
 }
 
-func (re *ruleEngine) Evaluate(ctx context.Context, input Input, op *evaluateOption) ([]*Output, *RuleEngineError) {
+func (re *ruleEngine) Evaluate(ctx context.Context, input Input, op *evaluateOption) ([]*Output, error) {
 	parsedInput, err := re.validateAndParseInput(input)
 	if err != nil {
 		return nil, err
 	}
+	outputs, err := re.evaluate(ctx, parsedInput, op)
+	return outputs, toError(err)
+}
+
+func (re *ruleEngine) EvaluateTyped(ctx context.Context, input TypedInput, op *evaluateOption) ([]*Output, error) {
+	parsedInput, err := re.validateTypedInput(input)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := re.evaluate(ctx, parsedInput, op)
+	return outputs, toError(err)
+}
+
+func (re *ruleEngine) EvaluateStruct(ctx context.Context, input any, op *evaluateOption) ([]*Output, error) {
+	parsedInput, err := re.validateAndParseStruct(input)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := re.evaluate(ctx, parsedInput, op)
+	return outputs, toError(err)
+}
+
+func (re *ruleEngine) EvaluateJSON(ctx context.Context, input []byte, op *evaluateOption) ([]*Output, error) {
+	parsedInput, err := re.validateAndParseJSON(input)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := re.evaluate(ctx, parsedInput, op)
+	return outputs, toError(err)
+}
+
+func (re *ruleEngine) evaluate(ctx context.Context, input *slotInput, op *evaluateOption) ([]*Output, *RuleEngineError) {
+	input.now = nowFromContext(ctx)
+	if op.cancellationCheckInterval > 0 {
+		input.check = &cancellationCheck{ctx: ctx, interval: op.cancellationCheckInterval}
+	}
+
+	if re.index == nil && op.workers > 1 && op.trace == nil && len(re.rules) > 1 {
+		return re.parallelEvaluation(ctx, input, op)
+	}
+	defer re.cacheStats.record(input)
+
+	if re.index != nil && op.trace == nil {
+		return re.indexedEvaluation(ctx, input, op)
+	}
 
 	if op.evalType == complete {
-		return re.ascendingEvaluation(ctx, parsedInput, len(re.rules))
+		return re.ascendingEvaluation(ctx, input, len(re.rules), op.trace)
 	} else if op.evalType == ascendingPriorityBased {
-		return re.ascendingEvaluation(ctx, parsedInput, op.limit)
+		return re.ascendingEvaluation(ctx, input, op.limit, op.trace)
 	} else {
-		return re.descendingEvaluation(ctx, parsedInput, op.limit)
+		return re.descendingEvaluation(ctx, input, op.limit, op.trace)
 	}
 }
 
-func (re *ruleEngine) ascendingEvaluation(ctx context.Context, input parsedInput, limit int) ([]*Output, *RuleEngineError) {
+func (re *ruleEngine) ascendingEvaluation(ctx context.Context, input *slotInput, limit int, trace *Trace) ([]*Output, *RuleEngineError) {
 	result := []*Output{}
 	for i := 0; i < len(re.rules); i++ {
 		rule := re.rules[i]
-		matched, err := rule.evaluate(ctx, input)
+		matched, err := rule.evaluateWithTrace(ctx, input, trace)
 		if err != nil {
 			return nil, err
 		}
@@ -124,10 +355,121 @@ func (re *ruleEngine) ascendingEvaluation(ctx context.Context, input parsedInput
 	return result, nil
 }
 
-func (re *ruleEngine) descendingEvaluation(ctx context.Context, input parsedInput, limit int) ([]*Output, *RuleEngineError) {
+func (re *ruleEngine) descendingEvaluation(ctx context.Context, input *slotInput, limit int, trace *Trace) ([]*Output, *RuleEngineError) {
 	result := []*Output{}
 	for i := len(re.rules) - 1; i >= 0; i-- {
 		rule := re.rules[i]
+		matched, err := rule.evaluateWithTrace(ctx, input, trace)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			result = append(result, newOutput(rule.name, rule.priority, rule.result))
+
+			if len(result) == limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// evaluates rules concurrently, workers take the next rule to evaluate as per evaluation type until every rule is
+// evaluated, hence rules evaluated before are done first and slow rules do not hold up rules of other workers. Matched
+// rules are considered in priority order as per evaluation type. Evaluation is stopped on first error.
+func (re *ruleEngine) parallelEvaluation(ctx context.Context, input *slotInput, op *evaluateOption) ([]*Output, *RuleEngineError) {
+	workers := op.workers
+	if workers > len(re.rules) {
+		workers = len(re.rules)
+	}
+
+	// cancelled on first error, so that other workers stop evaluating
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	matched := make([]bool, len(re.rules))
+	var next atomic.Int64
+	var firstErr *RuleEngineError
+	var errOnce sync.Once
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(input *slotInput) {
+			defer wg.Done()
+			defer re.cacheStats.record(input)
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(re.rules) {
+					return
+				}
+				if op.evalType == descendingPriorityBased {
+					i = len(re.rules) - 1 - i
+				}
+
+				result, err := re.rules[i].evaluate(ctx, input)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+				matched[i] = result
+			}
+		}(input.fork(ctx))
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	limit := op.limit
+	if op.evalType == complete {
+		limit = len(re.rules)
+	}
+
+	result := []*Output{}
+	for i := 0; i < len(re.rules); i++ {
+		index := i
+		if op.evalType == descendingPriorityBased {
+			index = len(re.rules) - 1 - i
+		}
+		if matched[index] {
+			rule := re.rules[index]
+			result = append(result, newOutput(rule.name, rule.priority, rule.result))
+
+			if len(result) == limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// evaluates candidate rules from index in priority order as per evaluation type
+func (re *ruleEngine) indexedEvaluation(ctx context.Context, input *slotInput, op *evaluateOption) ([]*Output, *RuleEngineError) {
+	candidates := re.index.candidates(input)
+	defer re.index.releaseCandidates(candidates)
+
+	limit := op.limit
+	if op.evalType == complete {
+		limit = len(re.rules)
+	}
+
+	// candidate positions in priority order as per evaluation type
+	next := func(position int) int { return candidates.next(position + 1) }
+	position := candidates.next(0)
+	if op.evalType == descendingPriorityBased {
+		next = func(position int) int { return candidates.prev(position - 1) }
+		position = candidates.prev(len(re.rules) - 1)
+	}
+
+	result := []*Output{}
+	for ; position >= 0; position = next(position) {
+		rule := re.rules[position]
 		matched, err := rule.evaluate(ctx, input)
 		if err != nil {
 			return nil, err
@@ -144,18 +486,53 @@ func (re *ruleEngine) descendingEvaluation(ctx context.Context, input parsedInpu
 	return result, nil
 }
 
-func (re *ruleEngine) EvaluateSingleRule(ctx context.Context, input Input, rulename string) (*Output, *RuleEngineError) {
+func (re *ruleEngine) EvaluateSingleRule(ctx context.Context, input Input, rulename string) (*Output, error) {
 	parsedInput, err := re.validateAndParseInput(input)
 	if err != nil {
 		return nil, err
 	}
+	output, err := re.evaluateSingleRule(ctx, parsedInput, rulename)
+	return output, toError(err)
+}
+
+func (re *ruleEngine) EvaluateSingleRuleTyped(ctx context.Context, input TypedInput, rulename string) (*Output, error) {
+	parsedInput, err := re.validateTypedInput(input)
+	if err != nil {
+		return nil, err
+	}
+	output, err := re.evaluateSingleRule(ctx, parsedInput, rulename)
+	return output, toError(err)
+}
+
+func (re *ruleEngine) EvaluateSingleRuleStruct(ctx context.Context, input any, rulename string) (*Output, error) {
+	parsedInput, err := re.validateAndParseStruct(input)
+	if err != nil {
+		return nil, err
+	}
+	output, err := re.evaluateSingleRule(ctx, parsedInput, rulename)
+	return output, toError(err)
+}
+
+func (re *ruleEngine) EvaluateSingleRuleJSON(ctx context.Context, input []byte, rulename string) (*Output, error) {
+	parsedInput, err := re.validateAndParseJSON(input)
+	if err != nil {
+		return nil, err
+	}
+	output, err := re.evaluateSingleRule(ctx, parsedInput, rulename)
+	return output, toError(err)
+}
+
+func (re *ruleEngine) evaluateSingleRule(ctx context.Context, input *slotInput, rulename string) (*Output, *RuleEngineError) {
+	input.now = nowFromContext(ctx)
 
 	rule, ok := re.ruleMap[rulename]
 	if !ok {
-		return nil, newError(ErrCodeRuleNotFound, fmt.Sprintf("RuleName: %v", rulename))
+		return nil, newError(ErrCodeRuleNotFound).withRule(rulename)
 	}
 
-	matched, err := rule.evaluate(ctx, parsedInput)
+	defer re.cacheStats.record(input)
+
+	matched, err := rule.evaluate(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -166,31 +543,144 @@ func (re *ruleEngine) EvaluateSingleRule(ctx context.Context, input Input, rulen
 	return nil, nil
 }
 
-// creates new rule engine using provided configuration
-func New(engineConfig *RuleEngineConfig) (RuleEngine, *RuleEngineError) {
+func (re *ruleEngine) ConditionCacheStats() ConditionCacheStats {
+	return re.cacheStats.stats()
+}
+
+// creates new rule engine using provided configuration and options
+func New(engineConfig *RuleEngineConfig, opts ...EngineOption) (RuleEngine, error) {
+	engine, err := newRuleEngine(engineConfig, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
 
-	if err := engineConfigValidator.validate(engineConfig); err != nil {
+func newRuleEngine(engineConfig *RuleEngineConfig, opts ...EngineOption) (*ruleEngine, *RuleEngineError) {
+	engineOp := newEngineOption(opts...)
+	// validation parses values into the configuration, caller's configuration is kept as is
+	engineConfig = engineConfig.clone()
+
+	if err := engineOp.registry.validator.validate(engineConfig, engineOp.dateTimeLayout); err != nil {
 		return nil, err
 	}
 
+	if engineOp.tieBreak == TieBreakError {
+		if collisions := priorityCollisions(engineConfig.Rules); len(collisions) != 0 {
+			// reports every collision, rule is same as the first one reported by Validate
+			err := newError(ErrCodeDuplicatePriority).withRule(collisions[0].ruleNames[0])
+			for _, collision := range collisions {
+				err.addMsg(collision.String())
+			}
+			return nil, err
+		}
+	}
+
 	engine := ruleEngine{
-		fields:  engineConfig.Fields,
-		ruleMap: map[string]*rule{},
-		rules:   []*rule{},
+		fields:         engineConfig.Fields,
+		fieldOptions:   engineConfig.FieldOptions,
+		dateTimeLayout: engineOp.dateTimeLayout,
+		bindings:       &structBindingCache{},
+		jsonPaths:      newJSONPathTree(engineConfig.Fields),
+		fieldSlots:     newFieldSlots(engineConfig.Fields),
+		slotFields:     newSlotFields(engineConfig.Fields),
+		cacheStats:     &conditionCacheStats{},
+
+		registry:         engineOp.registry,
+		conditionTypes:   engineConfig.ConditionTypes,
+		conditionIndexes: map[string]int{},
+
+		ruleMap:  map[string]*rule{},
+		rules:    []*rule{},
+		tieBreak: engineOp.tieBreak,
 	}
 
+	buildCtx := engine.newBuildContext()
+
+	declarationOrder := ruleDeclarationOrder(engineConfig)
 	for ruleName, r := range engineConfig.Rules {
-		ru, err := newRule(ruleName, r, engineConfig.ConditionTypes, engine.fields)
+		ru, err := newRule(ruleName, r, buildCtx)
 		if err != nil {
-			return nil, err
+			return nil, err.withRule(ruleName)
 		}
+		ru.order = declarationOrder[ruleName]
 		engine.ruleMap[ruleName] = ru
 		engine.rules = append(engine.rules, ru)
 	}
+	engine.nextOrder = len(declarationOrder)
+
+	engine.compileRules(buildCtx, engine.rules...)
 
 	sort.Slice(engine.rules, func(i, j int) bool {
-		return engine.rules[i].priority < engine.rules[j].priority
+		return engine.tieBreak.less(engine.rules[i], engine.rules[j])
 	})
 
+	if engineOp.ruleIndex {
+		engine.index = newRuleIndex(engine.rules, engine.fieldSlots)
+	}
+	engine.fieldReferences = make([]int, len(engine.slotFields))
+	for _, ru := range engine.rules {
+		engine.referenceFields(ru, 1)
+	}
+
 	return &engine, nil
 }
+
+// 'Validate' validates the configuration same as 'New' and reports every problem found, instead of failing on the
+// first one. Problems are ordered as fields, field options, condition types and rules, each ordered by name. Rules
+// having same priority are reported at last, if tie break policy is TieBreakError. Returns empty list for a valid
+// configuration.
+func Validate(engineConfig *RuleEngineConfig, opts ...EngineOption) []*ValidationError {
+	engineOp := newEngineOption(opts...)
+	// validation parses values into the configuration, caller's configuration is kept as is
+	engineConfig = engineConfig.clone()
+	ret := engineOp.registry.validator.validateAll(engineConfig, engineOp.dateTimeLayout)
+	if engineOp.tieBreak == TieBreakError {
+		ret = append(ret, priorityCollisionErrors(priorityCollisions(engineConfig.Rules))...)
+	}
+	return ret
+}
+
+// returns context to build rules with condition types of the engine
+func (re *ruleEngine) newBuildContext() *ruleBuildContext {
+	return &ruleBuildContext{
+		conditionTypes: re.conditionTypes,
+		fields:         re.fields,
+		fieldOptions:   re.fieldOptions,
+		evalFactory:    re.registry.evalFactory,
+		fieldSlots:     re.fieldSlots,
+	}
+}
+
+// adds 'delta' to reference count of fields, which are an operand of any condition type used by the rule
+func (re *ruleEngine) referenceFields(ru *rule, delta int) {
+	conditionTypeNames := map[string]bool{}
+	collectConditionTypes(ru.rootCondition, conditionTypeNames)
+
+	slots := map[int]bool{}
+	for conditionTypeName := range conditionTypeNames {
+		for _, operand := range ru.conditionTypes[conditionTypeName].Operands {
+			if operand.isField() {
+				slots[re.fieldSlots[operand.Val]] = true
+			}
+		}
+	}
+	for slot := range slots {
+		re.fieldReferences[slot] += delta
+	}
+}
+
+// reports whether field of the slot is an operand of any rule
+func (re *ruleEngine) isReferenced(slot int) bool {
+	return re.fieldReferences == nil || re.fieldReferences[slot] != 0
+}
+
+// compiles rules built with 'buildCtx', conditions are indexed by condition type name, hence rules compiled separately
+// share condition indexes
+func (re *ruleEngine) compileRules(buildCtx *ruleBuildContext, rules ...*rule) {
+	compiler := newProgramCompiler(re.fieldSlots, buildCtx.conditionIndex(re.conditionIndexes))
+	for _, ru := range rules {
+		ru.program = compiler.compileProgram(ru.rootEvaluator)
+	}
+	re.conditionCount = len(re.conditionIndexes)
+}
@@ -3,8 +3,13 @@ package ruleenginecore
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"sort"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func Test_rule_evaluate(t *testing.T) {
@@ -17,6 +22,7 @@ func Test_rule_evaluate(t *testing.T) {
 	type args struct {
 		ctx   context.Context
 		input parsedInput
+		check *cancellationCheck
 	}
 	tests := []struct {
 		name   string
@@ -25,7 +31,64 @@ func Test_rule_evaluate(t *testing.T) {
 		want   bool
 		want1  *RuleEngineError
 	}{
-		// TODO: Add test cases.
+		{
+			name: "valid_Matched",
+			fields: fields{
+				name:          discount10TestRule.name,
+				priority:      discount10TestRule.priority,
+				rootEvaluator: discount10TestRule.rootEvaluator,
+			},
+			args: args{
+				ctx:   context.TODO(),
+				input: parsedInput{"totalAmount": int64(25000), "IsHotelBooking": true, "PaxCount": int64(10)},
+			},
+			want:  true,
+			want1: nil,
+		},
+		{
+			name: "valid_NotMatched",
+			fields: fields{
+				name:          discount10TestRule.name,
+				priority:      discount10TestRule.priority,
+				rootEvaluator: discount10TestRule.rootEvaluator,
+			},
+			args: args{
+				ctx:   context.TODO(),
+				input: parsedInput{"totalAmount": int64(25000), "IsHotelBooking": true, "PaxCount": int64(1)},
+			},
+			want:  false,
+			want1: nil,
+		},
+		{
+			name: "invalid_ContextCancelled",
+			fields: fields{
+				name:          discount10TestRule.name,
+				priority:      discount10TestRule.priority,
+				rootEvaluator: discount10TestRule.rootEvaluator,
+			},
+			args: args{
+				ctx:   cancelledTestContext,
+				input: parsedInput{"totalAmount": int64(25000), "IsHotelBooking": true, "PaxCount": int64(10)},
+			},
+			want:  false,
+			want1: newError(ErrCodeContextCancelled),
+		},
+		{
+			name: "invalid_ContextCancelledWhileEvaluating",
+			fields: fields{
+				name:          discount10TestRule.name,
+				priority:      discount10TestRule.priority,
+				rootEvaluator: discount10TestRule.rootEvaluator,
+			},
+			args: args{
+				// context is checked by evaluators only
+				ctx:   context.TODO(),
+				input: parsedInput{"totalAmount": int64(25000), "IsHotelBooking": true, "PaxCount": int64(10)},
+				check: &cancellationCheck{ctx: cancelledTestContext, interval: 1},
+			},
+			want:  false,
+			want1: newError(ErrCodeContextCancelled),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -35,11 +98,15 @@ func Test_rule_evaluate(t *testing.T) {
 				rootEvaluator: tt.fields.rootEvaluator,
 				result:        tt.fields.result,
 			}
-			got, got1 := r.evaluate(tt.args.ctx, tt.args.input)
+			compiler := newProgramCompiler(testFieldSlots, map[evaluator]int{})
+			r.program = compiler.compileProgram(r.rootEvaluator)
+			input := testSlotInput(&ruleEngine{fieldSlots: testFieldSlots, conditionCount: compiler.conditionCount()}, tt.args.input)
+			input.check = tt.args.check
+			got, got1 := r.evaluate(tt.args.ctx, input)
 			if got != tt.want {
 				t.Errorf("rule.evaluate() got = %v, want %v", got, tt.want)
 			}
-			if !reflect.DeepEqual(got1, tt.want1) {
+			if !isErrorEqual(got1, tt.want1) {
 				t.Errorf("rule.evaluate() got1 = %v, want %v", got1, tt.want1)
 			}
 		})
@@ -48,7 +115,8 @@ func Test_rule_evaluate(t *testing.T) {
 
 func Test_ruleEngine_validateAndParseInput(t *testing.T) {
 	type fields struct {
-		fields Fields
+		fields       Fields
+		fieldOptions map[string]*FieldOption
 	}
 	type args struct {
 		input Input
@@ -107,23 +175,172 @@ func Test_ruleEngine_validateAndParseInput(t *testing.T) {
 			want:    nil,
 			wantErr: newError(ErrCodeParsingFailed),
 		},
+		{
+			name: "valid_DefaultValue",
+			fields: fields{
+				fields: Fields{
+					"totalAmount": Integer,
+					"PaxCount":    Integer,
+				},
+				fieldOptions: map[string]*FieldOption{
+					"PaxCount": {Default: testStringPtr("1"), typedDefault: int64(1)},
+				},
+			},
+			args: args{
+				input: Input{
+					"totalAmount": "100",
+				},
+			},
+			want: parsedInput{
+				"totalAmount": int64(100),
+				"PaxCount":    int64(1),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "valid_NullableField",
+			fields: fields{
+				fields: Fields{
+					"totalAmount": Integer,
+					"couponCode":  String,
+				},
+				fieldOptions: map[string]*FieldOption{
+					"couponCode": {Nullable: true},
+				},
+			},
+			args: args{
+				input: Input{
+					"totalAmount": "100",
+				},
+			},
+			want: parsedInput{
+				"totalAmount": int64(100),
+			},
+			wantErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			re := &ruleEngine{
-				fields: tt.fields.fields,
+				fields:       tt.fields.fields,
+				fieldOptions: tt.fields.fieldOptions,
+				fieldSlots:   newFieldSlots(tt.fields.fields),
+				slotFields:   newSlotFields(tt.fields.fields),
 			}
 			got, gotErr := re.validateAndParseInput(tt.args.input)
-			if !reflect.DeepEqual(got, tt.want) {
+			if !reflect.DeepEqual(testParsedValues(got), tt.want) {
 				t.Errorf("ruleEngine.validateAndParseInput() got = %v, want %v", got, tt.want)
 			}
-			if tt.wantErr != nil && gotErr.ErrCode != tt.wantErr.ErrCode {
+			if tt.wantErr != nil && !errors.Is(gotErr, tt.wantErr) {
 				t.Errorf("ruleEngine.validateAndParseInput() gotErr = %v, wantErr %v", gotErr, tt.wantErr)
 			}
 		})
 	}
 }
 
+func Test_ruleEngine_validateTypedInput(t *testing.T) {
+	type fields struct {
+		fields Fields
+	}
+	type args struct {
+		input TypedInput
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    parsedInput
+		wantErr *RuleEngineError
+	}{
+		{
+			name: "valid_TypedInput",
+			fields: fields{
+				fields: Fields{
+					"totalAmount":    Integer,
+					"IsHotelBooking": Boolean,
+					"categories":     StringList,
+				},
+			},
+			args: args{
+				input: TypedInput{
+					"totalAmount":    100,
+					"IsHotelBooking": true,
+					"categories":     []string{"hotel"},
+				},
+			},
+			want: parsedInput{
+				"totalAmount":    int64(100),
+				"IsHotelBooking": true,
+				"categories":     []any{"hotel"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid_FieldNotFound",
+			fields: fields{
+				fields: Fields{
+					"totalAmount": Integer,
+				},
+			},
+			args: args{
+				input: TypedInput{
+					"invalid": int64(100),
+				},
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeFieldNotFound),
+		},
+		{
+			name: "invalid_ValueType",
+			fields: fields{
+				fields: Fields{
+					"totalAmount": Integer,
+				},
+			},
+			args: args{
+				input: TypedInput{
+					"totalAmount": "100",
+				},
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeInvalidValueType),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := &ruleEngine{
+				fields:     tt.fields.fields,
+				fieldSlots: newFieldSlots(tt.fields.fields),
+				slotFields: newSlotFields(tt.fields.fields),
+			}
+			got, gotErr := re.validateTypedInput(tt.args.input)
+			if !reflect.DeepEqual(testParsedValues(got), tt.want) {
+				t.Errorf("ruleEngine.validateTypedInput() got = %v, want %v", got, tt.want)
+			}
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("ruleEngine.validateTypedInput() gotErr = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Benchmark_ruleEngine_validateAndParseInput(b *testing.B) {
+	fields := Fields{"totalAmount": Integer, "IsHotelBooking": Boolean, "PaxCount": Integer, "city": String}
+	re := &ruleEngine{
+		fields:     fields,
+		fieldSlots: newFieldSlots(fields),
+		slotFields: newSlotFields(fields),
+	}
+	input := Input{"totalAmount": "5000", "IsHotelBooking": "true", "PaxCount": "10", "city": "Delhi"}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := re.validateAndParseInput(input); err != nil {
+			b.Fatalf("validateAndParseInput() gotErr %v", err)
+		}
+	}
+}
+
 func Test_ruleEngine_Evaluate(t *testing.T) {
 	type fields struct {
 		fields  Fields
@@ -355,15 +572,18 @@ func Test_ruleEngine_Evaluate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			re := &ruleEngine{
-				fields:  tt.fields.fields,
-				ruleMap: tt.fields.ruleMap,
-				rules:   tt.fields.rules,
+				fields:         tt.fields.fields,
+				fieldSlots:     newFieldSlots(tt.fields.fields),
+				slotFields:     newSlotFields(tt.fields.fields),
+				conditionCount: testConditionCount,
+				ruleMap:        tt.fields.ruleMap,
+				rules:          tt.fields.rules,
 			}
 			got, gotErr := re.Evaluate(tt.args.ctx, tt.args.input, tt.args.op)
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ruleEngine.Evaluate() got = %v, want %v", got, tt.want)
 			}
-			if tt.wantErr != nil && gotErr.ErrCode != tt.wantErr.ErrCode {
+			if tt.wantErr != nil && !errors.Is(gotErr, tt.wantErr) {
 				t.Errorf("ruleEngine.Evaluate() gotErr = %v, wantErr %v", gotErr, tt.wantErr)
 			}
 		})
@@ -547,15 +767,18 @@ func Test_ruleEngine_EvaluateSingleRule(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			re := &ruleEngine{
-				fields:  tt.fields.fields,
-				ruleMap: tt.fields.ruleMap,
-				rules:   tt.fields.rules,
+				fields:         tt.fields.fields,
+				fieldSlots:     newFieldSlots(tt.fields.fields),
+				slotFields:     newSlotFields(tt.fields.fields),
+				conditionCount: testConditionCount,
+				ruleMap:        tt.fields.ruleMap,
+				rules:          tt.fields.rules,
 			}
 			got, gotErr := re.EvaluateSingleRule(tt.args.ctx, tt.args.input, tt.args.rulename)
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ruleEngine.EvaluateSingleRule() got = %v, want %v", got, tt.want)
 			}
-			if tt.wantErr != nil && gotErr.ErrCode != tt.wantErr.ErrCode {
+			if tt.wantErr != nil && !errors.Is(gotErr, tt.wantErr) {
 				t.Errorf("ruleEngine.EvaluateSingleRule() got1 = %v, want %v", gotErr, tt.wantErr)
 			}
 		})
@@ -563,6 +786,114 @@ func Test_ruleEngine_EvaluateSingleRule(t *testing.T) {
 }
 
 func TestCreateRuleEngine(t *testing.T) {
+	validSimpleConfig := &RuleEngineConfig{
+		Fields: Fields{
+			"totalAmount":    Integer,
+			"IsHotelBooking": Boolean,
+			"PaxCount":       Integer,
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"amountMoreThan20k": {
+				Operator: GreaterOperator,
+				Operands: []*Operand{
+					{
+						Type:      Field,
+						ValueType: Integer,
+						Val:       "totalAmount",
+					},
+					{
+						Type:      Constant,
+						ValueType: Integer,
+						Val:       "20000",
+					},
+				},
+			},
+			"HotelBooking": {
+				Operator: EqualOperator,
+				Operands: []*Operand{
+					{
+						Type:      Field,
+						ValueType: Boolean,
+						Val:       "IsHotelBooking",
+					},
+					{
+						Type:      Constant,
+						ValueType: Boolean,
+						Val:       "true",
+					},
+				},
+			},
+			"PaxCountMoreThan5": {
+				Operator: GreaterOperator,
+				Operands: []*Operand{
+					{
+						Type:      Field,
+						ValueType: Integer,
+						Val:       "PaxCount",
+					},
+					{
+						Type:      Constant,
+						ValueType: Integer,
+						Val:       "5",
+					},
+				},
+			},
+		},
+		Rules: map[string]*RuleConfig{
+			"Discount10": {
+				Priority: 1,
+				RootCondition: &Condition{
+					Type: AndCondition,
+					SubConditions: []*Condition{
+						{
+							Type: "amountMoreThan20k",
+						},
+						{
+							Type: "HotelBooking",
+						},
+						{
+							Type: "PaxCountMoreThan5",
+						},
+					},
+				},
+				Result: map[string]any{
+					"discount": 10,
+				},
+			},
+			"Discount5": {
+				Priority: 2,
+				RootCondition: &Condition{
+					Type: AndCondition,
+					SubConditions: []*Condition{
+						{
+							Type: "amountMoreThan20k",
+						},
+						{
+							Type: "HotelBooking",
+						},
+						{
+							Type: NegationCondition,
+							SubConditions: []*Condition{
+								{
+									Type: "PaxCountMoreThan5",
+								},
+							},
+						},
+					},
+				},
+				Result: map[string]any{
+					"discount": 5,
+				},
+			},
+		},
+	}
+
+	// engine is built with validated copy of the config
+	validatedConfig := validSimpleConfig.clone()
+	if err := defaultRegistry.validator.validate(validatedConfig, time.RFC3339); err != nil {
+		t.Fatalf("validate() gotErr %v", err)
+	}
+
 	type args struct {
 		engineConfig *RuleEngineConfig
 	}
@@ -575,107 +906,7 @@ func TestCreateRuleEngine(t *testing.T) {
 		{
 			name: "ValidSimpleRuleEngine",
 			args: args{
-				engineConfig: &RuleEngineConfig{
-					Fields: Fields{
-						"totalAmount":    Integer,
-						"IsHotelBooking": Boolean,
-						"PaxCount":       Integer,
-					},
-					ConditionTypes: map[string]*ConditionType{
-						"amountMoreThan20k": {
-							Operator: GreaterOperator,
-							Operands: []*Operand{
-								{
-									Type:      Field,
-									ValueType: Integer,
-									Val:       "totalAmount",
-								},
-								{
-									Type:      Constant,
-									ValueType: Integer,
-									Val:       "20000",
-								},
-							},
-						},
-						"HotelBooking": {
-							Operator: EqualOperator,
-							Operands: []*Operand{
-								{
-									Type:      Field,
-									ValueType: Boolean,
-									Val:       "IsHotelBooking",
-								},
-								{
-									Type:      Constant,
-									ValueType: Boolean,
-									Val:       "true",
-								},
-							},
-						},
-						"PaxCountMoreThan5": {
-							Operator: GreaterOperator,
-							Operands: []*Operand{
-								{
-									Type:      Field,
-									ValueType: Integer,
-									Val:       "PaxCount",
-								},
-								{
-									Type:      Constant,
-									ValueType: Integer,
-									Val:       "5",
-								},
-							},
-						},
-					},
-					Rules: map[string]*RuleConfig{
-						"Discount10": {
-							Priority: 1,
-							RootCondition: &Condition{
-								Type: AndCondition,
-								SubConditions: []*Condition{
-									{
-										Type: "amountMoreThan20k",
-									},
-									{
-										Type: "HotelBooking",
-									},
-									{
-										Type: "PaxCountMoreThan5",
-									},
-								},
-							},
-							Result: map[string]any{
-								"discount": 10,
-							},
-						},
-						"Discount5": {
-							Priority: 2,
-							RootCondition: &Condition{
-								Type: AndCondition,
-								SubConditions: []*Condition{
-									{
-										Type: "amountMoreThan20k",
-									},
-									{
-										Type: "HotelBooking",
-									},
-									{
-										Type: NegationCondition,
-										SubConditions: []*Condition{
-											{
-												Type: "PaxCountMoreThan5",
-											},
-										},
-									},
-								},
-							},
-							Result: map[string]any{
-								"discount": 5,
-							},
-						},
-					},
-				},
+				engineConfig: validSimpleConfig,
 			},
 			want: &ruleEngine{
 				fields: map[string]ValueType{
@@ -683,14 +914,35 @@ func TestCreateRuleEngine(t *testing.T) {
 					"IsHotelBooking": Boolean,
 					"PaxCount":       Integer,
 				},
+				fieldReferences: []int{2, 2, 2},
+				dateTimeLayout:  time.RFC3339,
+				bindings:        &structBindingCache{},
+				jsonPaths: newJSONPathTree(Fields{
+					"totalAmount":    Integer,
+					"IsHotelBooking": Boolean,
+					"PaxCount":       Integer,
+				}),
+				fieldSlots:     testFieldSlots,
+				slotFields:     testSlotFields,
+				conditionCount: 3,
+				cacheStats:     &conditionCacheStats{},
+				registry:       defaultRegistry,
+				conditionTypes: validatedConfig.ConditionTypes,
+				conditionIndexes: map[string]int{
+					"HotelBooking":      0,
+					"PaxCountMoreThan5": 1,
+					"amountMoreThan20k": 2,
+				},
 				ruleMap: map[string]*rule{
-					"Discount10": discount10TestRule,
-					"Discount5":  discount5TestRule,
+					"Discount10": testRuleWithConditions(discount10TestRule, validatedConfig),
+					"Discount5":  testRuleWithConditions(discount5TestRule, validatedConfig),
 				},
 				rules: []*rule{
-					discount10TestRule,
-					discount5TestRule,
+					testRuleWithConditions(discount10TestRule, validatedConfig),
+					testRuleWithConditions(discount5TestRule, validatedConfig),
 				},
+				tieBreak:  TieBreakByName,
+				nextOrder: 2,
 			},
 			wantErr: nil,
 		},
@@ -743,6 +995,35 @@ func TestCreateRuleEngine(t *testing.T) {
 			want:    nil,
 			wantErr: newError(ErrCodeConditionTypeNotFound),
 		},
+		{
+			name: "Invalid_MatchOperatorPattern",
+			args: args{
+				engineConfig: &RuleEngineConfig{
+					Fields: Fields{
+						"promoCode": String,
+					},
+					ConditionTypes: map[string]*ConditionType{
+						"promoCodeFormat": {
+							Operator: MatchOperator,
+							Operands: []*Operand{
+								{
+									Type:      Field,
+									ValueType: String,
+									Val:       "promoCode",
+								},
+								{
+									Type:      Constant,
+									ValueType: String,
+									Val:       "^PROMO(",
+								},
+							},
+						},
+					},
+				},
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -750,13 +1031,58 @@ func TestCreateRuleEngine(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("New() got = %v, want %v", got, tt.want)
 			}
-			if tt.wantErr != nil && gotErr.ErrCode != tt.wantErr.ErrCode {
+			if tt.wantErr != nil && !errors.Is(gotErr, tt.wantErr) {
 				t.Errorf("New() got1 = %v, want %v", gotErr, tt.wantErr)
 			}
 		})
 	}
 }
 
+// returns copy of rule having condition tree from the config
+func testRuleWithConditions(r *rule, config *RuleEngineConfig) *rule {
+	ret := *r
+	ret.rootCondition = config.Rules[r.name].RootCondition
+	ret.conditionTypes = config.ConditionTypes
+	ret.order = ruleDeclarationOrder(config)[r.name]
+
+	// conditions are indexed in condition type name order
+	names := []string{}
+	for name := range config.ConditionTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	index := map[string]int{}
+	for i, name := range names {
+		index[name] = i
+	}
+	conditions := map[evaluator]int{}
+	testIndexConditions(ret.rootCondition, r.rootEvaluator, index, conditions)
+	ret.program = newProgramCompiler(testFieldSlots, conditions).compileProgram(r.rootEvaluator)
+	return &ret
+}
+
+func testIndexConditions(condition *Condition, eval evaluator, index map[string]int, ret map[evaluator]int) {
+	if le, ok := eval.(*logicalEvaluator); ok {
+		for i, subCondition := range condition.SubConditions {
+			testIndexConditions(subCondition, le.innerEvaluators[i], index, ret)
+		}
+		return
+	}
+	ret[eval] = index[condition.Type]
+}
+
+var testFieldSlots = newFieldSlots(Fields{
+	"totalAmount":    Integer,
+	"IsHotelBooking": Boolean,
+	"PaxCount":       Integer,
+})
+
+var testSlotFields = newSlotFields(Fields{
+	"totalAmount":    Integer,
+	"IsHotelBooking": Boolean,
+	"PaxCount":       Integer,
+})
+
 var discount10TestRule = &rule{
 	name:     "Discount10",
 	priority: 1,
@@ -938,8 +1264,535 @@ var discount2TestRule = &rule{
 
 var cancelledTestContext context.Context
 
+// count of conditions compiled for test rules
+var testConditionCount int
+
 func init() {
 	testContext, canFunc := context.WithCancel(context.Background())
 	canFunc()
 	cancelledTestContext = testContext
+
+	compiler := newProgramCompiler(testFieldSlots, map[evaluator]int{})
+	for _, r := range []*rule{discount10TestRule, discount5TestRule, discount2TestRule} {
+		r.program = compiler.compileProgram(r.rootEvaluator)
+	}
+	testConditionCount = compiler.conditionCount()
+}
+
+func TestRuleEngine_DateTimeAndDuration(t *testing.T) {
+	engine, err := New(&RuleEngineConfig{
+		Fields: Fields{
+			"createdAt":     DateTime,
+			"bookingWindow": Duration,
+		},
+		// default value is parsed with DateTime layout of the engine
+		FieldOptions: map[string]*FieldOption{
+			"createdAt": {Default: testStringPtr("2023-03-10")},
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"createdInLast30Days": {
+				Operator: GreaterOperator,
+				Operands: []*Operand{
+					{
+						Type:      Field,
+						ValueType: DateTime,
+						Val:       "createdAt",
+					},
+					{
+						Type:      Now,
+						ValueType: DateTime,
+						Val:       "-30d",
+					},
+				},
+			},
+			"bookingWindowAtMost48h": {
+				Operator: LessEqualOperator,
+				Operands: []*Operand{
+					{
+						Type:      Field,
+						ValueType: Duration,
+						Val:       "bookingWindow",
+					},
+					{
+						Type:      Constant,
+						ValueType: Duration,
+						Val:       "48h",
+					},
+				},
+			},
+		},
+		Rules: map[string]*RuleConfig{
+			"newUserLastMinuteBooking": {
+				Priority: 1,
+				RootCondition: &Condition{
+					Type: AndCondition,
+					SubConditions: []*Condition{
+						{Type: "createdInLast30Days"},
+						{Type: "bookingWindowAtMost48h"},
+					},
+				},
+			},
+		},
+	}, WithDateTimeLayout("2006-01-02"))
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+
+	ctx := ContextWithNow(context.TODO(), time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name    string
+		input   Input
+		want    bool
+		wantErr *RuleEngineError
+	}{
+		{
+			name:  "matched",
+			input: Input{"createdAt": "2023-03-01", "bookingWindow": "24h"},
+			want:  true,
+		},
+		{
+			name:  "notMatched_oldUser",
+			input: Input{"createdAt": "2023-01-01", "bookingWindow": "24h"},
+			want:  false,
+		},
+		{
+			name:  "notMatched_bookingWindow",
+			input: Input{"createdAt": "2023-03-01", "bookingWindow": "3d"},
+			want:  false,
+		},
+		{
+			name:  "matched_defaultCreatedAt",
+			input: Input{"bookingWindow": "24h"},
+			want:  true,
+		},
+		{
+			name:    "invalid_dateTimeLayout",
+			input:   Input{"createdAt": "2023-03-01T00:00:00Z", "bookingWindow": "24h"},
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := engine.EvaluateSingleRule(ctx, tt.input, "newUserLastMinuteBooking")
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("EvaluateSingleRule() gotErr %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if (got != nil) != tt.want {
+				t.Errorf("EvaluateSingleRule() got %v, want matched %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleEngine_ListValueType(t *testing.T) {
+	engine, err := New(&RuleEngineConfig{
+		Fields: Fields{
+			"itemCategories": StringList,
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"hasElectronics": {
+				Operator: AnyOfOperator,
+				Operands: []*Operand{
+					{
+						Type:      Field,
+						ValueType: StringList,
+						Val:       "itemCategories",
+					},
+					{
+						Type:      Constant,
+						ValueType: StringList,
+						Val:       "electronics",
+					},
+				},
+			},
+			"moreThan2Items": {
+				Operator: SizeGreaterOperator,
+				Operands: []*Operand{
+					{
+						Type:      Field,
+						ValueType: StringList,
+						Val:       "itemCategories",
+					},
+					{
+						Type:      Constant,
+						ValueType: Integer,
+						Val:       "2",
+					},
+				},
+			},
+		},
+		Rules: map[string]*RuleConfig{
+			"electronicsBundle": {
+				Priority: 1,
+				RootCondition: &Condition{
+					Type: AndCondition,
+					SubConditions: []*Condition{
+						{Type: "hasElectronics"},
+						{Type: "moreThan2Items"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   Input
+		want    bool
+		wantErr *RuleEngineError
+	}{
+		{
+			name:  "matched",
+			input: Input{"itemCategories": "books,electronics,toys"},
+			want:  true,
+		},
+		{
+			name:  "notMatched_noElectronics",
+			input: Input{"itemCategories": "books,toys,games"},
+			want:  false,
+		},
+		{
+			name:  "notMatched_size",
+			input: Input{"itemCategories": "electronics"},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := engine.EvaluateSingleRule(context.TODO(), tt.input, "electronicsBundle")
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("EvaluateSingleRule() gotErr %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if (got != nil) != tt.want {
+				t.Errorf("EvaluateSingleRule() got %v, want matched %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleEngine_EvaluateTyped(t *testing.T) {
+	engine := &ruleEngine{
+		fields: map[string]ValueType{
+			"totalAmount":    Integer,
+			"IsHotelBooking": Boolean,
+			"PaxCount":       Integer,
+		},
+		fieldSlots:     testFieldSlots,
+		slotFields:     testSlotFields,
+		conditionCount: testConditionCount,
+		ruleMap: map[string]*rule{
+			"Discount10": discount10TestRule,
+			"Discount5":  discount5TestRule,
+		},
+		rules: []*rule{
+			discount10TestRule,
+			discount5TestRule,
+		},
+	}
+	input := TypedInput{
+		"totalAmount":    int64(25000),
+		"IsHotelBooking": true,
+		"PaxCount":       3,
+	}
+
+	got, gotErr := engine.EvaluateTyped(context.TODO(), input, EvaluateOptions().Complete())
+	if gotErr != nil {
+		t.Fatalf("ruleEngine.EvaluateTyped() gotErr = %v", gotErr)
+	}
+	want := []*Output{newOutput("Discount5", 2, discount5TestRule.result)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ruleEngine.EvaluateTyped() got = %v, want %v", got, want)
+	}
+
+	gotSingle, gotErr := engine.EvaluateSingleRuleTyped(context.TODO(), input, "Discount10")
+	if gotErr != nil || gotSingle != nil {
+		t.Errorf("ruleEngine.EvaluateSingleRuleTyped() got = %v, gotErr = %v, want nil", gotSingle, gotErr)
+	}
+}
+
+func testStringPtr(val string) *string {
+	return &val
+}
+
+func TestRuleEngine_OptionalFields(t *testing.T) {
+	engine, err := New(&RuleEngineConfig{
+		Fields: Fields{
+			"totalAmount": Integer,
+			"PaxCount":    Integer,
+			"couponCode":  String,
+			// mandatory field, which is not referenced by any rule, hence input is not required to have it
+			"channel": String,
+		},
+		FieldOptions: map[string]*FieldOption{
+			"PaxCount":   {Default: testStringPtr("1")},
+			"couponCode": {Nullable: true},
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"soloTraveller": {
+				Operator: EqualOperator,
+				Operands: []*Operand{
+					{Type: Field, ValueType: Integer, Val: "PaxCount"},
+					{Type: Constant, ValueType: Integer, Val: "1"},
+				},
+			},
+			"notFestiveCoupon": {
+				Operator: NotEqualOperator,
+				Operands: []*Operand{
+					{Type: Field, ValueType: String, Val: "couponCode"},
+					{Type: Constant, ValueType: String, Val: "FESTIVE"},
+				},
+			},
+			"festiveCoupon": {
+				Operator: EqualOperator,
+				Operands: []*Operand{
+					{Type: Field, ValueType: String, Val: "couponCode"},
+					{Type: Constant, ValueType: String, Val: "FESTIVE"},
+				},
+			},
+			"amountMoreThan1000": {
+				Operator: GreaterOperator,
+				Operands: []*Operand{
+					{Type: Field, ValueType: Integer, Val: "totalAmount"},
+					{Type: Constant, ValueType: Integer, Val: "1000"},
+				},
+			},
+			"couponMissing": {
+				Operator: IsMissingOperator,
+				Operands: []*Operand{
+					{Type: Field, ValueType: String, Val: "couponCode"},
+				},
+			},
+		},
+		Rules: map[string]*RuleConfig{
+			"soloWithOtherCoupon": {
+				Priority: 1,
+				RootCondition: &Condition{
+					Type: AndCondition,
+					SubConditions: []*Condition{
+						{Type: "soloTraveller"},
+						{Type: "notFestiveCoupon"},
+					},
+				},
+			},
+			"soloWithoutCoupon": {
+				Priority: 2,
+				RootCondition: &Condition{
+					Type: AndCondition,
+					SubConditions: []*Condition{
+						{Type: "soloTraveller"},
+						{Type: "couponMissing"},
+					},
+				},
+			},
+			"highAmount": {
+				Priority:      3,
+				RootCondition: &Condition{Type: "amountMoreThan1000"},
+			},
+			// comparison against missing field is false, hence its negation is true
+			"withoutFestiveCoupon": {
+				Priority: 4,
+				RootCondition: &Condition{
+					Type:          NegationCondition,
+					SubConditions: []*Condition{{Type: "festiveCoupon"}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   Input
+		want    []string
+		wantErr *RuleEngineError
+	}{
+		{
+			name:  "defaultAndMissing",
+			input: Input{"totalAmount": "100"},
+			want:  []string{"soloWithoutCoupon", "withoutFestiveCoupon"},
+		},
+		{
+			name:  "presentCoupon",
+			input: Input{"totalAmount": "100", "couponCode": "SUMMER"},
+			want:  []string{"soloWithOtherCoupon", "withoutFestiveCoupon"},
+		},
+		{
+			name:  "festiveCoupon",
+			input: Input{"totalAmount": "100", "couponCode": "FESTIVE", "channel": "app"},
+			want:  []string{},
+		},
+		{
+			name:  "overriddenDefault",
+			input: Input{"totalAmount": "100", "PaxCount": "2"},
+			want:  []string{"withoutFestiveCoupon"},
+		},
+		{
+			name:    "invalid_MandatoryFieldNotFound",
+			input:   Input{"PaxCount": "2"},
+			wantErr: newError(ErrCodeFieldNotFound),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := engine.Evaluate(context.TODO(), tt.input, EvaluateOptions().Complete())
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("Evaluate() gotErr %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if gotErr != nil {
+				return
+			}
+			gotNames := []string{}
+			for _, output := range got {
+				gotNames = append(gotNames, output.Rulename)
+			}
+			if !reflect.DeepEqual(gotNames, tt.want) {
+				t.Errorf("Evaluate() got %v, want %v", gotNames, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ruleEngine_ascendingEvaluation_NoAllocation(t *testing.T) {
+	re := &ruleEngine{
+		fieldSlots:     testFieldSlots,
+		slotFields:     testSlotFields,
+		conditionCount: testConditionCount,
+		rules:          []*rule{discount10TestRule, discount5TestRule, discount2TestRule},
+	}
+	input := testSlotInput(re, parsedInput{"totalAmount": int64(5000), "IsHotelBooking": true, "PaxCount": int64(10)})
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := re.ascendingEvaluation(context.TODO(), input, len(re.rules), nil); err != nil {
+			t.Fatalf("ascendingEvaluation() gotErr %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("ascendingEvaluation() allocations = %v, want 0", allocs)
+	}
+}
+
+func Benchmark_ruleEngine_ascendingEvaluation(b *testing.B) {
+	re := &ruleEngine{
+		fieldSlots:     testFieldSlots,
+		slotFields:     testSlotFields,
+		conditionCount: testConditionCount,
+		rules:          []*rule{discount10TestRule, discount5TestRule, discount2TestRule},
+	}
+	input := testSlotInput(re, parsedInput{"totalAmount": int64(5000), "IsHotelBooking": true, "PaxCount": int64(10)})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = re.ascendingEvaluation(ctx, input, len(re.rules), nil)
+	}
+}
+
+func TestRuleEngine_ParallelEvaluation(t *testing.T) {
+	config := &RuleEngineConfig{
+		Fields:         Fields{"totalAmount": Integer},
+		ConditionTypes: map[string]*ConditionType{},
+		Rules:          map[string]*RuleConfig{},
+	}
+	for i := 0; i < 50; i++ {
+		name := "amountMoreThan" + strconv.Itoa(i*100)
+		config.ConditionTypes[name] = &ConditionType{
+			Operator: GreaterOperator,
+			Operands: []*Operand{
+				{Type: Field, ValueType: Integer, Val: "totalAmount"},
+				{Type: Constant, ValueType: Integer, Val: strconv.Itoa(i * 100)},
+			},
+		}
+		config.Rules["rule"+strconv.Itoa(i)] = &RuleConfig{
+			Priority:      i,
+			RootCondition: &Condition{Type: name},
+		}
+	}
+	engine, err := New(config)
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+
+	input := Input{"totalAmount": "2550"}
+	options := map[string]*evaluateOption{
+		"complete":   EvaluateOptions().Complete(),
+		"ascending":  EvaluateOptions().AscendingPriorityBased(5),
+		"descending": EvaluateOptions().DescendingPriorityBased(5),
+	}
+	for name, op := range options {
+		want, wantErr := engine.Evaluate(context.TODO(), input, op)
+		if wantErr != nil {
+			t.Fatalf("Evaluate() gotErr %v", wantErr)
+		}
+
+		for _, workers := range []int{2, 3, 8, 100} {
+			t.Run(name+"_"+strconv.Itoa(workers), func(t *testing.T) {
+				got, gotErr := engine.Evaluate(context.TODO(), input, op.WithParallel(workers).WithCancellationCheck(1))
+				if gotErr != nil {
+					t.Fatalf("Evaluate() gotErr %v", gotErr)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Evaluate() got %v, want %v", got, want)
+				}
+			})
+		}
+	}
+
+	_, gotErr := engine.Evaluate(cancelledTestContext, input, EvaluateOptions().Complete().WithParallel(4))
+	if !isErrorEqual(gotErr, newError(ErrCodeContextCancelled)) {
+		t.Errorf("Evaluate() gotErr %v, wantErr %v", gotErr, newError(ErrCodeContextCancelled))
+	}
+}
+
+func TestRuleEngine_ParallelEvaluation_StopsOnError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// every evaluation after the first one fails, as context is cancelled by the first evaluation
+	var evaluated atomic.Int64
+	registry := NewOperatorRegistry()
+	if err := registry.RegisterOperator("cancel", OperatorSpec{
+		OperandCount: 1,
+		ValueTypes:   []ValueType{Integer},
+		Evaluate: func(values []any) bool {
+			evaluated.Add(1)
+			cancel()
+			return true
+		},
+	}); err != nil {
+		t.Fatalf("OperatorRegistry.RegisterOperator() gotErr %v", err)
+	}
+
+	// every rule has its own condition type, as outcome of a condition type is memoized per evaluation
+	ruleCount := 1000
+	config := &RuleEngineConfig{
+		Fields:         Fields{"totalAmount": Integer},
+		ConditionTypes: map[string]*ConditionType{},
+		Rules:          map[string]*RuleConfig{},
+	}
+	for i := 0; i < ruleCount; i++ {
+		conditionTypeName := "cancel" + strconv.Itoa(i)
+		config.ConditionTypes[conditionTypeName] = &ConditionType{Operator: "cancel", Operands: []*Operand{
+			{Type: Field, ValueType: Integer, Val: "totalAmount"},
+		}}
+		config.Rules["rule"+strconv.Itoa(i)] = &RuleConfig{Priority: i, RootCondition: &Condition{Type: conditionTypeName}}
+	}
+	engine, err := New(config, WithOperatorRegistry(registry))
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+
+	_, gotErr := engine.Evaluate(ctx, Input{"totalAmount": "100"}, EvaluateOptions().Complete().WithParallel(4))
+	if !isErrorEqual(gotErr, newError(ErrCodeContextCancelled)) {
+		t.Errorf("Evaluate() gotErr %v, wantErr %v", gotErr, newError(ErrCodeContextCancelled))
+	}
+	// at most a rule per worker is evaluated after the context is cancelled
+	if got := evaluated.Load(); got > 4 {
+		t.Errorf("Evaluate() evaluated %v rules after error, want at most 4", got)
+	}
 }
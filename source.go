@@ -0,0 +1,215 @@
+package ruleenginecore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 'ConfigSource' provides RuleEngineConfig from a backend, such as file system.
+type ConfigSource interface {
+	// 'Load' loads current configuration from the source
+	Load(ctx context.Context) (*RuleEngineConfig, error)
+
+	// 'Watch' watches the source for configuration changes, every change is sent as ConfigUpdate on returned channel.
+	// Channel is closed once ctx is done.
+	Watch(ctx context.Context) <-chan ConfigUpdate
+}
+
+// 'ConfigUpdate' is a change of configuration, either changed configuration or an error while loading the change
+type ConfigUpdate struct {
+	Config *RuleEngineConfig
+	Err    error
+}
+
+// 'FileConfigSource' is a ConfigSource for a JSON file or a directory of JSON files, which polls file system for changes.
+//
+// For a directory, every '.json' file (hidden files are ignored) is considered as part of RuleEngineConfig, such as
+// a file per rule having only the rule in 'rules'. Fields, field options, condition types and rules of all the files
+// are merged, defining same element in multiple files results into an error.
+type FileConfigSource struct {
+	path         string
+	pollInterval time.Duration
+}
+
+// poll interval of FileConfigSource, when provided interval is not positive
+const defaultPollInterval = time.Second
+
+// creates file config source for a JSON file or a directory at 'path', which is checked for changes once in every
+// 'pollInterval'. Non-positive 'pollInterval' is considered as one second.
+func NewFileConfigSource(path string, pollInterval time.Duration) *FileConfigSource {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	return &FileConfigSource{path: path, pollInterval: pollInterval}
+}
+
+func (source *FileConfigSource) Load(ctx context.Context) (*RuleEngineConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, newError(ErrCodeContextCancelled, "Context cancelled while loading config").wrap(err)
+	}
+
+	files, err := source.readFiles()
+	if err != nil {
+		return nil, err
+	}
+	config, err := mergeConfigFiles(files)
+	return config, toError(err)
+}
+
+func (source *FileConfigSource) Watch(ctx context.Context) <-chan ConfigUpdate {
+	// changes are considered relative to files as of now
+	var lastDigest []byte
+	if files, err := source.readFiles(); err == nil {
+		lastDigest = configFilesDigest(files)
+	}
+
+	updates := make(chan ConfigUpdate)
+	go func() {
+		defer close(updates)
+
+		ticker := time.NewTicker(source.pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			// failure to read files is notified once, until files are readable again
+			var update ConfigUpdate
+			files, err := source.readFiles()
+			if err != nil {
+				if lastDigest == nil {
+					continue
+				}
+				lastDigest = nil
+				update.Err = err
+			} else {
+				digest := configFilesDigest(files)
+				if bytes.Equal(digest, lastDigest) {
+					continue
+				}
+				lastDigest = digest
+				config, err := mergeConfigFiles(files)
+				update = ConfigUpdate{Config: config, Err: toError(err)}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case updates <- update:
+			}
+		}
+	}()
+	return updates
+}
+
+// config file name and content
+type configFile struct {
+	name    string
+	content []byte
+}
+
+// reads the file or '.json' files of the directory in name order
+func (source *FileConfigSource) readFiles() ([]configFile, *RuleEngineError) {
+	info, err := os.Stat(source.path)
+	if err != nil {
+		return nil, newError(ErrCodeLoadConfigFailed).wrap(err)
+	}
+
+	names := []string{source.path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(source.path)
+		if err != nil {
+			return nil, newError(ErrCodeLoadConfigFailed).wrap(err)
+		}
+
+		// entries are sorted by name
+		names = names[:0]
+		for _, entry := range entries {
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || filepath.Ext(name) != ".json" {
+				continue
+			}
+			names = append(names, filepath.Join(source.path, name))
+		}
+	}
+
+	ret := make([]configFile, 0, len(names))
+	for _, name := range names {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return nil, newError(ErrCodeLoadConfigFailed).wrap(err)
+		}
+		ret = append(ret, configFile{name: name, content: content})
+	}
+	return ret, nil
+}
+
+func configFilesDigest(files []configFile) []byte {
+	hash := sha256.New()
+	for _, file := range files {
+		fmt.Fprintf(hash, "%v:%v:", file.name, len(file.content))
+		hash.Write(file.content)
+	}
+	return hash.Sum(nil)
+}
+
+// merges configurations of the files, element defined in multiple files results into an error. Rules are considered
+// declared in the order of files.
+func mergeConfigFiles(files []configFile) (*RuleEngineConfig, *RuleEngineError) {
+	ret := &RuleEngineConfig{
+		Fields:         Fields{},
+		FieldOptions:   map[string]*FieldOption{},
+		ConditionTypes: map[string]*ConditionType{},
+		Rules:          map[string]*RuleConfig{},
+	}
+
+	for _, file := range files {
+		config := &RuleEngineConfig{}
+		if err := json.Unmarshal(file.content, config); err != nil {
+			return nil, newError(ErrCodeParsingFailed, fmt.Sprintf("file: %v", file.name)).wrap(err)
+		}
+
+		if err := mergeConfigElements(ret.Fields, config.Fields, "field", file.name); err != nil {
+			return nil, err
+		}
+		if err := mergeConfigElements(ret.FieldOptions, config.FieldOptions, "fieldOption", file.name); err != nil {
+			return nil, err
+		}
+		if err := mergeConfigElements(ret.ConditionTypes, config.ConditionTypes, "conditionType", file.name); err != nil {
+			return nil, err
+		}
+		if err := mergeConfigElements(ret.Rules, config.Rules, "rule", file.name); err != nil {
+			return nil, err
+		}
+		ret.ruleOrder = append(ret.ruleOrder, config.ruleOrder...)
+	}
+	return ret, nil
+}
+
+func mergeConfigElements[T any](ret map[string]T, elements map[string]T, kind string, fileName string) *RuleEngineError {
+	names := make([]string, 0, len(elements))
+	for name := range elements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := ret[name]; ok {
+			return newError(ErrCodeLoadConfigFailed,
+				fmt.Sprintf("%v: %v defined again in file: %v", kind, name, fileName))
+		}
+		ret[name] = elements[name]
+	}
+	return nil
+}
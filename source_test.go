@@ -0,0 +1,180 @@
+package ruleenginecore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testSourceFieldsConfig = `{
+	"fields": {"amount": "int"},
+	"conditionTypes": {
+		"amountMoreThan100": {"operator": ">", "operands": [
+			{"type": "field", "valuetype": "int", "value": "amount"},
+			{"type": "constant", "valuetype": "int", "value": "100"}
+		]}
+	}
+}`
+
+const testSourceRuleConfig = `{"rules": {"bigAmount": {"priority": 1, "condition": {"type": "amountMoreThan100"}}}}`
+
+func testWriteFile(t *testing.T, name string, content string) {
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatalf("write file gotErr %v", err)
+	}
+}
+
+func TestFileConfigSource_Load(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		wantRules []string
+		wantErr   *RuleEngineError
+	}{
+		{
+			name: "Directory",
+			files: map[string]string{
+				"config.json":    testSourceFieldsConfig,
+				"bigAmount.json": testSourceRuleConfig,
+				"readme.txt":     "ignored",
+				".hidden.json":   "ignored",
+			},
+			wantRules: []string{"bigAmount"},
+		},
+		{
+			name: "DuplicateRule",
+			files: map[string]string{
+				"config.json":    testSourceFieldsConfig,
+				"bigAmount.json": testSourceRuleConfig,
+				"copy.json":      testSourceRuleConfig,
+			},
+			wantErr: newError(ErrCodeLoadConfigFailed),
+		},
+		{
+			name: "InvalidJSON",
+			files: map[string]string{
+				"config.json": `{"fields": `,
+			},
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				testWriteFile(t, filepath.Join(dir, name), content)
+			}
+
+			got, gotErr := NewFileConfigSource(dir, time.Second).Load(context.TODO())
+			if tt.wantErr != nil {
+				if !errors.Is(gotErr, tt.wantErr) {
+					t.Errorf("Load() gotErr %v, wantErr %v", gotErr, tt.wantErr)
+				}
+				return
+			}
+
+			engine, err := New(got)
+			if gotErr != nil || err != nil {
+				t.Fatalf("Load() gotErr %v, New() gotErr %v", gotErr, err)
+			}
+			if gotRules := testMatchedRules(t, engine, Input{"amount": "150"}); !reflect.DeepEqual(gotRules, tt.wantRules) {
+				t.Errorf("engine matched %v, want %v", gotRules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestFileConfigSource_NonPositivePollInterval(t *testing.T) {
+	dir := t.TempDir()
+	testWriteFile(t, filepath.Join(dir, "config.json"), testSourceFieldsConfig)
+
+	for _, pollInterval := range []time.Duration{0, -time.Second} {
+		source := NewFileConfigSource(dir, pollInterval)
+		if source.pollInterval != defaultPollInterval {
+			t.Errorf("NewFileConfigSource() pollInterval = %v, want %v", source.pollInterval, defaultPollInterval)
+		}
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		updates := source.Watch(ctx)
+		cancel()
+		for range updates {
+		}
+	}
+}
+
+func TestFileConfigSource_LoadCancelled(t *testing.T) {
+	dir := t.TempDir()
+	testWriteFile(t, filepath.Join(dir, "config.json"), testSourceFieldsConfig)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if _, gotErr := NewFileConfigSource(dir, time.Second).Load(ctx); !errors.Is(gotErr, ErrContextCancelled) {
+		t.Errorf("Load() gotErr %v, wantErr %v", gotErr, ErrContextCancelled)
+	}
+	if _, gotErr := NewFileConfigSource(dir, time.Second).Load(ctx); !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("Load() gotErr %v, wantErr %v", gotErr, context.Canceled)
+	}
+}
+
+func TestReloadableEngine_WatchSource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	testWriteFile(t, file, testSourceFieldsConfig)
+
+	source := NewFileConfigSource(file, 10*time.Millisecond)
+	config, err := source.Load(context.TODO())
+	if err != nil {
+		t.Fatalf("Load() gotErr %v", err)
+	}
+	engine, err := NewReloadable(config)
+	if err != nil {
+		t.Fatalf("NewReloadable() gotErr %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	reloads := make(chan error)
+	go engine.WatchSource(ctx, source, func(err error) {
+		reloads <- err
+	})
+
+	// wait for the watch to start, changes are considered once watch is started
+	time.Sleep(50 * time.Millisecond)
+
+	testWriteFile(t, file, `{"rules": `)
+	select {
+	case err := <-reloads:
+		if !errors.Is(err, ErrParsingFailed) {
+			t.Errorf("reload gotErr %v, wantErr %v", err, newError(ErrCodeParsingFailed))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("reload is not notified")
+	}
+	// current engine is kept as is
+	if version := engine.Version(); version != 1 {
+		t.Errorf("Version() = %v, want 1", version)
+	}
+
+	testWriteFile(t, file, testSourceFieldsConfig[:len(testSourceFieldsConfig)-1]+`, "rules": {
+		"bigAmount": {"priority": 1, "condition": {"type": "amountMoreThan100"}}
+	}}`)
+	select {
+	case err := <-reloads:
+		if err != nil {
+			t.Fatalf("reload gotErr %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("reload is not notified")
+	}
+
+	if version := engine.Version(); version != 2 {
+		t.Errorf("Version() = %v, want 2", version)
+	}
+	wantRules := []string{"bigAmount"}
+	if gotRules := testMatchedRules(t, engine, Input{"amount": "150"}); !reflect.DeepEqual(gotRules, wantRules) {
+		t.Errorf("engine matched %v, want %v", gotRules, wantRules)
+	}
+}
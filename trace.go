@@ -0,0 +1,165 @@
+package ruleenginecore
+
+import (
+	"regexp"
+	"time"
+)
+
+// 'Trace' records evaluation of rules, it is filled by evaluation having option 'WithTrace'
+type Trace struct {
+	// traces of evaluated rules in evaluation order
+	Rules []*RuleTrace `json:"rules"`
+}
+
+// 'RuleTrace' records evaluation of a rule
+type RuleTrace struct {
+	Rulename string `json:"rulename"`
+	Priority int    `json:"priority"`
+	Matched  bool   `json:"matched"`
+
+	// trace tree mirroring the rule condition tree
+	Condition *ConditionTrace `json:"condition"`
+}
+
+// 'ConditionTrace' records evaluation of a condition
+type ConditionTrace struct {
+	// condition type, either logical such as 'and','or','not' or name of ConditionType
+	Type string `json:"type"`
+
+	// operator of ConditionType, empty for logical condition
+	Operator string `json:"operator,omitempty"`
+
+	// operands of ConditionType with resolved values, empty for logical condition
+	Operands []*OperandTrace `json:"operands,omitempty"`
+
+	// outcome of the condition, false if condition is not evaluated
+	Result bool `json:"result"`
+
+	// false if condition is short-circuited by logical condition
+	Evaluated bool `json:"evaluated"`
+
+	SubConditions []*ConditionTrace `json:"subConditions,omitempty"`
+}
+
+// 'OperandTrace' records an operand and its resolved value
+type OperandTrace struct {
+	Type      OperandType `json:"type"`
+	ValueType ValueType   `json:"valueType"`
+	Val       string      `json:"val"`
+
+	// typed value for Constant operands (ex. list of values for 'in' operator, pattern for 'match' operator), resolved
+	// value for Field and Now operands. Null for missing fields, Field and Now operands of conditions which are not
+	// evaluated
+	Value any `json:"value"`
+}
+
+func (r *rule) evaluateTrace(input parsedInput) *RuleTrace {
+	conditionTrace := traceCondition(r.rootCondition, r.rootEvaluator, r.conditionTypes, input)
+	return &RuleTrace{
+		Rulename:  r.name,
+		Priority:  r.priority,
+		Matched:   conditionTrace.Result,
+		Condition: conditionTrace,
+	}
+}
+
+// evaluates the condition along with its evaluator and records trace, evaluation short-circuits the same way as
+// logicalEvaluator does
+func traceCondition(condition *Condition, eval evaluator, conditionTypes map[string]*ConditionType, input parsedInput) *ConditionTrace {
+	ct := &ConditionTrace{Type: condition.Type, Evaluated: true}
+
+	logicalEval, ok := eval.(*logicalEvaluator)
+	if !ok {
+		conditionType := conditionTypes[condition.Type]
+		ct.Operator = conditionType.Operator
+		ct.Operands = traceOperands(conditionType.Operands, input)
+		ct.Result = eval.evaluate(input)
+		return ct
+	}
+
+	shortCircuited := false
+	for i, subCondition := range condition.SubConditions {
+		if shortCircuited {
+			ct.SubConditions = append(ct.SubConditions, skippedCondition(subCondition, conditionTypes))
+			continue
+		}
+
+		subTrace := traceCondition(subCondition, logicalEval.innerEvaluators[i], conditionTypes, input)
+		ct.SubConditions = append(ct.SubConditions, subTrace)
+
+		switch condition.Type {
+		case OrCondition:
+			shortCircuited = subTrace.Result
+		case AndCondition:
+			shortCircuited = !subTrace.Result
+		}
+	}
+
+	switch condition.Type {
+	case OrCondition:
+		ct.Result = shortCircuited
+	case AndCondition:
+		ct.Result = !shortCircuited
+	case NegationCondition:
+		ct.Result = !ct.SubConditions[0].Result
+	}
+	return ct
+}
+
+// records trace for the condition which is not evaluated
+func skippedCondition(condition *Condition, conditionTypes map[string]*ConditionType) *ConditionTrace {
+	ct := &ConditionTrace{Type: condition.Type}
+	if conditionType, ok := conditionTypes[condition.Type]; ok {
+		ct.Operator = conditionType.Operator
+		ct.Operands = traceOperands(conditionType.Operands, nil)
+		return ct
+	}
+
+	for _, subCondition := range condition.SubConditions {
+		ct.SubConditions = append(ct.SubConditions, skippedCondition(subCondition, conditionTypes))
+	}
+	return ct
+}
+
+// records operands with resolved values, values of Field and Now operands are not resolved if input is nil
+func traceOperands(operands []*Operand, input parsedInput) []*OperandTrace {
+	ret := make([]*OperandTrace, 0, len(operands))
+	for _, operand := range operands {
+		ot := &OperandTrace{Type: operand.Type, ValueType: operand.ValueType, Val: operand.Val}
+		if operand.Type == Constant {
+			ot.Value = traceConstant(operand)
+		} else if input != nil {
+			if operand.isField() {
+				ot.Value = traceValue(input[operand.Val])
+			} else {
+				ot.Value = traceValue(operand.getValue(input))
+			}
+		}
+		ret = append(ret, ot)
+	}
+	return ret
+}
+
+// returns typed value of constant operand in JSON friendly representation, values of set are in the order of operand
+// value
+func traceConstant(operand *Operand) any {
+	switch val := operand.typedValue.(type) {
+	case *regexp.Regexp:
+		return val.String()
+	case set[any]:
+		values := make([]any, len(operand.typedValues))
+		for i, value := range operand.typedValues {
+			values[i] = traceValue(value)
+		}
+		return values
+	}
+	return traceValue(operand.typedValue)
+}
+
+// converts value to JSON friendly representation
+func traceValue(val any) any {
+	if d, ok := val.(time.Duration); ok {
+		return d.String()
+	}
+	return val
+}
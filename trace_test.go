@@ -0,0 +1,187 @@
+package ruleenginecore
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRuleEngine_EvaluateWithTrace(t *testing.T) {
+	engine, err := New(&RuleEngineConfig{
+		Fields: Fields{
+			"totalAmount": Integer,
+			"PaxCount":    Integer,
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"amountMoreThan20k": {
+				Operator: GreaterOperator,
+				Operands: []*Operand{
+					{Type: Field, ValueType: Integer, Val: "totalAmount"},
+					{Type: Constant, ValueType: Integer, Val: "20000"},
+				},
+			},
+			"PaxCountMoreThan5": {
+				Operator: GreaterOperator,
+				Operands: []*Operand{
+					{Type: Field, ValueType: Integer, Val: "PaxCount"},
+					{Type: Constant, ValueType: Integer, Val: "5"},
+				},
+			},
+		},
+		Rules: map[string]*RuleConfig{
+			"Discount10": {
+				Priority: 1,
+				RootCondition: &Condition{
+					Type: AndCondition,
+					SubConditions: []*Condition{
+						{Type: "amountMoreThan20k"},
+						{Type: "PaxCountMoreThan5"},
+					},
+				},
+			},
+			"Discount5": {
+				Priority: 2,
+				RootCondition: &Condition{
+					Type: OrCondition,
+					SubConditions: []*Condition{
+						{Type: NegationCondition, SubConditions: []*Condition{{Type: "amountMoreThan20k"}}},
+						{Type: "PaxCountMoreThan5"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+
+	trace := &Trace{}
+	got, gotErr := engine.Evaluate(context.TODO(), Input{"totalAmount": "10000", "PaxCount": "2"},
+		EvaluateOptions().Complete().WithTrace(trace))
+	if gotErr != nil {
+		t.Fatalf("Evaluate() gotErr %v", gotErr)
+	}
+	if len(got) != 1 || got[0].Rulename != "Discount5" {
+		t.Errorf("Evaluate() got %v, want [Discount5]", got)
+	}
+
+	amountOperands := func(value any) []*OperandTrace {
+		return []*OperandTrace{
+			{Type: Field, ValueType: Integer, Val: "totalAmount", Value: value},
+			{Type: Constant, ValueType: Integer, Val: "20000", Value: int64(20000)},
+		}
+	}
+	paxOperands := func(value any) []*OperandTrace {
+		return []*OperandTrace{
+			{Type: Field, ValueType: Integer, Val: "PaxCount", Value: value},
+			{Type: Constant, ValueType: Integer, Val: "5", Value: int64(5)},
+		}
+	}
+
+	want := &Trace{
+		Rules: []*RuleTrace{
+			{
+				Rulename: "Discount10",
+				Priority: 1,
+				Matched:  false,
+				Condition: &ConditionTrace{
+					Type:      AndCondition,
+					Evaluated: true,
+					SubConditions: []*ConditionTrace{
+						{Type: "amountMoreThan20k", Operator: GreaterOperator, Operands: amountOperands(int64(10000)), Evaluated: true},
+						{Type: "PaxCountMoreThan5", Operator: GreaterOperator, Operands: paxOperands(nil)},
+					},
+				},
+			},
+			{
+				Rulename: "Discount5",
+				Priority: 2,
+				Matched:  true,
+				Condition: &ConditionTrace{
+					Type:      OrCondition,
+					Result:    true,
+					Evaluated: true,
+					SubConditions: []*ConditionTrace{
+						{
+							Type:      NegationCondition,
+							Result:    true,
+							Evaluated: true,
+							SubConditions: []*ConditionTrace{
+								{Type: "amountMoreThan20k", Operator: GreaterOperator, Operands: amountOperands(int64(10000)), Evaluated: true},
+							},
+						},
+						{Type: "PaxCountMoreThan5", Operator: GreaterOperator, Operands: paxOperands(nil)},
+					},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(trace, want) {
+		gotJSON, _ := json.Marshal(trace)
+		wantJSON, _ := json.Marshal(want)
+		t.Errorf("Evaluate() trace got %s, want %s", gotJSON, wantJSON)
+	}
+
+	if _, err := json.Marshal(trace); err != nil {
+		t.Errorf("json.Marshal() trace gotErr %v", err)
+	}
+}
+
+func Test_traceOperands_Constant(t *testing.T) {
+	tests := []struct {
+		name          string
+		conditionType *ConditionType
+		want          any
+	}{
+		{
+			name: "in",
+			conditionType: &ConditionType{Operator: InOperator, Operands: []*Operand{
+				{Type: Field, ValueType: String, Val: "city"},
+				{Type: Constant, ValueType: String, Val: "Pune,Delhi,Mumbai"},
+			}},
+			want: []any{"Pune", "Delhi", "Mumbai"},
+		},
+		{
+			name: "anyOf",
+			conditionType: &ConditionType{Operator: AnyOfOperator, Operands: []*Operand{
+				{Type: Field, ValueType: StringList, Val: "tags"},
+				{Type: Constant, ValueType: StringList, Val: "vip,new,gold"},
+			}},
+			want: []any{"vip", "new", "gold"},
+		},
+		{
+			name: "match",
+			conditionType: &ConditionType{Operator: MatchOperator, Operands: []*Operand{
+				{Type: Field, ValueType: String, Val: "city"},
+				{Type: Constant, ValueType: String, Val: "^Pu.*"},
+			}},
+			want: "^Pu.*",
+		},
+		{
+			name: "duration",
+			conditionType: &ConditionType{Operator: GreaterOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Duration, Val: "window"},
+				{Type: Constant, ValueType: Duration, Val: "90m"},
+			}},
+			want: "1h30m0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := Fields{"city": String, "window": Duration, "tags": StringList}
+			if err := defaultRegistry.validator.validateConditionType(tt.conditionType, fields); err != nil {
+				t.Fatalf("validateConditionType() gotErr %v", err)
+			}
+
+			// constant value is recorded irrespective of input
+			for _, input := range []parsedInput{nil, {"city": "Pune"}} {
+				got := traceOperands(tt.conditionType.Operands, input)
+				if !reflect.DeepEqual(got[secondOperand].Value, tt.want) {
+					t.Errorf("traceOperands() constant value got %v, want %v", got[secondOperand].Value, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package ruleenginecore
+
+import "sort"
+
+// 'UpdatableRuleEngine' is a RuleEngine, which derives new engines having rules or condition types updated. Every
+// update returns a new engine, the engine itself is kept as is, hence it is safe to update an engine while it is
+// evaluating.
+type UpdatableRuleEngine interface {
+	RuleEngine
+
+	// 'AddRule' returns a new engine having the rule added. Only the added rule is validated and built, other rules are
+	// shared with the engine. Adding already existing rule results into an error.
+	AddRule(ruleName string, ruleConfig *RuleConfig) (UpdatableRuleEngine, error)
+
+	// 'ReplaceRule' returns a new engine having existing rule replaced with given rule.
+	ReplaceRule(ruleName string, ruleConfig *RuleConfig) (UpdatableRuleEngine, error)
+
+	// 'RemoveRule' returns a new engine without the rule.
+	RemoveRule(ruleName string) (UpdatableRuleEngine, error)
+
+	// 'SetConditionType' returns a new engine having condition type added or replaced. Rules having the condition type
+	// are rebuilt.
+	SetConditionType(conditionTypeName string, conditionType *ConditionType) (UpdatableRuleEngine, error)
+}
+
+// creates new updatable rule engine using provided configuration and options, same as 'New'
+func NewUpdatable(engineConfig *RuleEngineConfig, opts ...EngineOption) (UpdatableRuleEngine, error) {
+	engine, err := newRuleEngine(engineConfig, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
+
+func (re *ruleEngine) AddRule(ruleName string, ruleConfig *RuleConfig) (UpdatableRuleEngine, error) {
+	if _, ok := re.ruleMap[ruleName]; ok {
+		return nil, newError(ErrCodeRuleAlreadyExist).withRule(ruleName)
+	}
+	return re.withRule(ruleName, ruleConfig)
+}
+
+func (re *ruleEngine) ReplaceRule(ruleName string, ruleConfig *RuleConfig) (UpdatableRuleEngine, error) {
+	if _, ok := re.ruleMap[ruleName]; !ok {
+		return nil, newError(ErrCodeRuleNotFound).withRule(ruleName)
+	}
+	return re.withRule(ruleName, ruleConfig)
+}
+
+func (re *ruleEngine) RemoveRule(ruleName string) (UpdatableRuleEngine, error) {
+	if _, ok := re.ruleMap[ruleName]; !ok {
+		return nil, newError(ErrCodeRuleNotFound).withRule(ruleName)
+	}
+
+	engine := re.clone()
+	removed := engine.ruleMap[ruleName]
+	engine.removeRule(ruleName)
+	engine.updateRules([]*rule{removed}, nil)
+	return engine, nil
+}
+
+func (re *ruleEngine) SetConditionType(conditionTypeName string, conditionType *ConditionType) (UpdatableRuleEngine, error) {
+	if conditionType == nil {
+		return nil, newError(ErrCodeInvalidConditionType, "condition type is not defined").withConditionType(conditionTypeName)
+	}
+	// validation parses values into the condition type, caller's condition type is kept as is
+	conditionType = conditionType.clone()
+	if err := re.registry.validator.validateConditionType(conditionType, re.fields); err != nil {
+		return nil, err.withConditionType(conditionTypeName)
+	}
+
+	engine := re.clone()
+	engine.conditionTypes = make(map[string]*ConditionType, len(re.conditionTypes)+1)
+	for name, ct := range re.conditionTypes {
+		engine.conditionTypes[name] = ct
+	}
+	engine.conditionTypes[conditionTypeName] = conditionType
+
+	// rules having the condition type are rebuilt, other rules are kept as is
+	buildCtx := engine.newBuildContext()
+	positions := []int{}
+	replaced, rebuilt := []*rule{}, []*rule{}
+	for position, ru := range engine.rules {
+		if !hasConditionType(ru.rootCondition, conditionTypeName) {
+			continue
+		}
+
+		ruleConfig := &RuleConfig{Priority: ru.priority, RootCondition: ru.rootCondition, Result: ru.result}
+		rebuiltRule, err := newRule(ru.name, ruleConfig, buildCtx)
+		if err != nil {
+			return nil, err.withRule(ru.name)
+		}
+		rebuiltRule.order = ru.order
+		positions = append(positions, position)
+		replaced = append(replaced, ru)
+		rebuilt = append(rebuilt, rebuiltRule)
+	}
+	engine.compileRules(buildCtx, rebuilt...)
+
+	for i, ru := range rebuilt {
+		engine.rules[positions[i]] = ru
+		engine.ruleMap[ru.name] = ru
+	}
+	engine.updateRules(replaced, rebuilt)
+	return engine, nil
+}
+
+// validates and builds the rule, then adds the rule to the engine copy replacing existing rule having same name.
+// Replaced rule keeps its declaration order, otherwise rule is considered declared after existing rules.
+func (re *ruleEngine) withRule(ruleName string, ruleConfig *RuleConfig) (UpdatableRuleEngine, error) {
+	if err := re.registry.validator.validateRule(ruleConfig); err != nil {
+		return nil, err.withRule(ruleName)
+	}
+	if re.tieBreak == TieBreakError {
+		for _, ru := range re.rules {
+			if ru.priority == ruleConfig.Priority && ru.name != ruleName {
+				collision := &priorityCollision{priority: ru.priority, ruleNames: []string{ru.name, ruleName}}
+				return nil, newError(ErrCodeDuplicatePriority, collision.String()).withRule(ruleName)
+			}
+		}
+	}
+
+	engine := re.clone()
+	buildCtx := engine.newBuildContext()
+	ru, err := newRule(ruleName, ruleConfig, buildCtx)
+	if err != nil {
+		return nil, err.withRule(ruleName)
+	}
+	engine.compileRules(buildCtx, ru)
+
+	removed := []*rule{}
+	if existing, ok := engine.ruleMap[ruleName]; ok {
+		ru.order = existing.order
+		removed = append(removed, existing)
+	} else {
+		ru.order = engine.nextOrder
+		engine.nextOrder++
+	}
+
+	engine.removeRule(ruleName)
+	engine.insertRule(ru)
+	engine.updateRules(removed, []*rule{ru})
+	return engine, nil
+}
+
+// returns copy of the engine to be updated, rules and condition indexes are copied, other state is shared as it is
+// never mutated once engine is created. Built rules are shared as well, only added or rebuilt rules are new.
+func (re *ruleEngine) clone() *ruleEngine {
+	engine := *re
+	engine.cacheStats = &conditionCacheStats{}
+
+	engine.conditionIndexes = make(map[string]int, len(re.conditionIndexes))
+	for name, index := range re.conditionIndexes {
+		engine.conditionIndexes[name] = index
+	}
+
+	engine.ruleMap = make(map[string]*rule, len(re.ruleMap))
+	for name, ru := range re.ruleMap {
+		engine.ruleMap[name] = ru
+	}
+
+	engine.rules = make([]*rule, len(re.rules), len(re.rules)+1)
+	copy(engine.rules, re.rules)
+	return &engine
+}
+
+// removes rule having the name if exists, order of other rules is kept as is
+func (re *ruleEngine) removeRule(ruleName string) {
+	ru, ok := re.ruleMap[ruleName]
+	if !ok {
+		return
+	}
+	delete(re.ruleMap, ruleName)
+
+	position := sort.Search(len(re.rules), func(i int) bool {
+		return re.rules[i].priority >= ru.priority
+	})
+	for ; position < len(re.rules); position++ {
+		if re.rules[position] == ru {
+			re.rules = append(re.rules[:position], re.rules[position+1:]...)
+			return
+		}
+	}
+}
+
+// inserts rule in ascending priority order, rules having same priority are ordered as per tie break policy
+func (re *ruleEngine) insertRule(ru *rule) {
+	position := sort.Search(len(re.rules), func(i int) bool {
+		return re.tieBreak.less(ru, re.rules[i])
+	})
+	re.rules = append(re.rules, nil)
+	copy(re.rules[position+1:], re.rules[position:])
+	re.rules[position] = ru
+	re.ruleMap[ru.name] = ru
+}
+
+// updates rule index and field references for removed and added rules, once rules of the engine are updated. Index
+// entries and references of other rules are kept as is.
+func (re *ruleEngine) updateRules(removed, added []*rule) {
+	if re.index != nil {
+		re.index = re.index.update(re.rules, re.fieldSlots, removed, added)
+	}
+
+	fieldReferences := re.fieldReferences
+	re.fieldReferences = make([]int, len(fieldReferences))
+	copy(re.fieldReferences, fieldReferences)
+	for _, ru := range removed {
+		re.referenceFields(ru, -1)
+	}
+	for _, ru := range added {
+		re.referenceFields(ru, 1)
+	}
+}
+
+// reports whether condition tree has a condition of the condition type
+func hasConditionType(condition *Condition, conditionTypeName string) bool {
+	if condition.Type == conditionTypeName {
+		return true
+	}
+	for _, subCondition := range condition.SubConditions {
+		if hasConditionType(subCondition, conditionTypeName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,398 @@
+package ruleenginecore
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var _ UpdatableRuleEngine = (*ruleEngine)(nil)
+
+func testUpdateConfig() *RuleEngineConfig {
+	return &RuleEngineConfig{
+		Fields: Fields{"amount": Integer, "city": String},
+		ConditionTypes: map[string]*ConditionType{
+			"amountMoreThan100": {Operator: GreaterOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Integer, Val: "amount"},
+				{Type: Constant, ValueType: Integer, Val: "100"},
+			}},
+			"cityDelhi": {Operator: EqualOperator, Operands: []*Operand{
+				{Type: Field, ValueType: String, Val: "city"},
+				{Type: Constant, ValueType: String, Val: "Delhi"},
+			}},
+		},
+		Rules: map[string]*RuleConfig{
+			"amountRule": {Priority: 1, RootCondition: &Condition{Type: "amountMoreThan100"}},
+			"bothRule": {Priority: 2, RootCondition: &Condition{Type: AndCondition, SubConditions: []*Condition{
+				{Type: "amountMoreThan100"}, {Type: "cityDelhi"},
+			}}},
+			"cityRule": {Priority: 3, RootCondition: &Condition{Type: "cityDelhi"}},
+		},
+	}
+}
+
+func TestRuleEngine_Update(t *testing.T) {
+	tests := []struct {
+		name      string
+		update    func(UpdatableRuleEngine) (UpdatableRuleEngine, error)
+		wantRules []string
+		wantErr   *RuleEngineError
+	}{
+		{
+			name: "AddRule",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.AddRule("anyRule", &RuleConfig{Priority: 2, RootCondition: &Condition{
+					Type: OrCondition, SubConditions: []*Condition{{Type: "amountMoreThan100"}, {Type: "cityDelhi"}},
+				}})
+			},
+			wantRules: []string{"amountRule", "anyRule", "bothRule", "cityRule"},
+		},
+		{
+			name: "AddRule_AlreadyExist",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.AddRule("cityRule", &RuleConfig{Priority: 1, RootCondition: &Condition{Type: "cityDelhi"}})
+			},
+			wantErr: newError(ErrCodeRuleAlreadyExist),
+		},
+		{
+			name: "AddRule_ConditionTypeNotFound",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.AddRule("newRule", &RuleConfig{Priority: 1, RootCondition: &Condition{Type: "unknown"}})
+			},
+			wantErr: newError(ErrCodeConditionTypeNotFound),
+		},
+		{
+			name: "AddRule_Nil",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.AddRule("newRule", nil)
+			},
+			wantErr: newError(ErrCodeInvalidConditionType),
+		},
+		{
+			name: "AddRule_WithoutCondition",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.AddRule("newRule", &RuleConfig{Priority: 2})
+			},
+			wantErr: newError(ErrCodeInvalidConditionType),
+		},
+		{
+			name: "ReplaceRule_Nil",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.ReplaceRule("cityRule", nil)
+			},
+			wantErr: newError(ErrCodeInvalidConditionType),
+		},
+		{
+			name: "AddRule_InvalidSubConditionCount",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.AddRule("newRule", &RuleConfig{Priority: 1, RootCondition: &Condition{Type: AndCondition}})
+			},
+			wantErr: newError(ErrCodeInvalidSubConditionCount),
+		},
+		{
+			name: "ReplaceRule",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.ReplaceRule("amountRule", &RuleConfig{Priority: 4, RootCondition: &Condition{
+					Type: NegationCondition, SubConditions: []*Condition{{Type: "amountMoreThan100"}},
+				}})
+			},
+			wantRules: []string{"bothRule", "cityRule"},
+		},
+		{
+			name: "ReplaceRule_NotFound",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.ReplaceRule("unknown", &RuleConfig{Priority: 1, RootCondition: &Condition{Type: "cityDelhi"}})
+			},
+			wantErr: newError(ErrCodeRuleNotFound),
+		},
+		{
+			name: "RemoveRule",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.RemoveRule("bothRule")
+			},
+			wantRules: []string{"amountRule", "cityRule"},
+		},
+		{
+			name: "RemoveRule_NotFound",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.RemoveRule("unknown")
+			},
+			wantErr: newError(ErrCodeRuleNotFound),
+		},
+		{
+			name: "SetConditionType_Replace",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.SetConditionType("amountMoreThan100", &ConditionType{Operator: GreaterOperator, Operands: []*Operand{
+					{Type: Field, ValueType: Integer, Val: "amount"},
+					{Type: Constant, ValueType: Integer, Val: "200"},
+				}})
+			},
+			wantRules: []string{"cityRule"},
+		},
+		{
+			name: "SetConditionType_Add",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				engine, err := engine.SetConditionType("cityMumbai", &ConditionType{Operator: EqualOperator, Operands: []*Operand{
+					{Type: Field, ValueType: String, Val: "city"},
+					{Type: Constant, ValueType: String, Val: "Mumbai"},
+				}})
+				if err != nil {
+					return nil, err
+				}
+				return engine.AddRule("mumbaiRule", &RuleConfig{Priority: 0, RootCondition: &Condition{
+					Type: NegationCondition, SubConditions: []*Condition{{Type: "cityMumbai"}},
+				}})
+			},
+			wantRules: []string{"mumbaiRule", "amountRule", "bothRule", "cityRule"},
+		},
+		{
+			name: "SetConditionType_Invalid",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.SetConditionType("cityDelhi", &ConditionType{Operator: EqualOperator, Operands: []*Operand{
+					{Type: Field, ValueType: String, Val: "unknown"},
+					{Type: Constant, ValueType: String, Val: "Delhi"},
+				}})
+			},
+			wantErr: newError(ErrCodeFieldNotFound),
+		},
+		{
+			name: "SetConditionType_Nil",
+			update: func(engine UpdatableRuleEngine) (UpdatableRuleEngine, error) {
+				return engine.SetConditionType("cityDelhi", nil)
+			},
+			wantErr: newError(ErrCodeInvalidConditionType),
+		},
+	}
+	input := Input{"amount": "150", "city": "Delhi"}
+	for _, tt := range tests {
+		for _, opts := range [][]EngineOption{{}, {WithRuleIndex()}} {
+			t.Run(tt.name, func(t *testing.T) {
+				engine, err := NewUpdatable(testUpdateConfig(), opts...)
+				if err != nil {
+					t.Fatalf("NewUpdatable() gotErr %v", err)
+				}
+
+				got, gotErr := tt.update(engine)
+				if tt.wantErr != nil {
+					if !errors.Is(gotErr, tt.wantErr) {
+						t.Fatalf("update gotErr %v, wantErr %v", gotErr, tt.wantErr)
+					}
+					return
+				}
+				if gotErr != nil {
+					t.Fatalf("update gotErr %v", gotErr)
+				}
+
+				if gotRules := testMatchedRules(t, got, input); !reflect.DeepEqual(gotRules, tt.wantRules) {
+					t.Errorf("updated engine matched %v, want %v", gotRules, tt.wantRules)
+				}
+
+				// engine is kept as is
+				wantRules := []string{"amountRule", "bothRule", "cityRule"}
+				if gotRules := testMatchedRules(t, engine, input); !reflect.DeepEqual(gotRules, wantRules) {
+					t.Errorf("engine matched %v, want %v", gotRules, wantRules)
+				}
+			})
+		}
+	}
+}
+
+func TestRuleEngine_SetConditionType_KeepsConditionType(t *testing.T) {
+	engine, err := NewUpdatable(testUpdateConfig())
+	if err != nil {
+		t.Fatalf("NewUpdatable() gotErr %v", err)
+	}
+
+	conditionType := &ConditionType{Operator: InOperator, Operands: []*Operand{
+		{Type: Field, ValueType: String, Val: "city"},
+		{Type: Constant, ValueType: String, Val: "Delhi,Mumbai"},
+	}}
+	for i := 0; i < 2; i++ {
+		if engine, err = engine.SetConditionType("cityDelhi", conditionType); err != nil {
+			t.Fatalf("SetConditionType() gotErr %v", err)
+		}
+	}
+	if operand := conditionType.Operands[1]; operand.typedValue != nil {
+		t.Errorf("SetConditionType() parsed operand of the condition type %v", operand.typedValue)
+	}
+
+	wantRules := []string{"cityRule"}
+	if gotRules := testMatchedRules(t, engine, Input{"amount": "50", "city": "Mumbai"}); !reflect.DeepEqual(gotRules, wantRules) {
+		t.Errorf("engine matched %v, want %v", gotRules, wantRules)
+	}
+}
+
+func TestReloadableEngine_Update(t *testing.T) {
+	engine, err := NewReloadable(testUpdateConfig())
+	if err != nil {
+		t.Fatalf("NewReloadable() gotErr %v", err)
+	}
+
+	if err := engine.RemoveRule("unknown"); !errors.Is(err, ErrRuleNotFound) {
+		t.Errorf("RemoveRule() gotErr %v, wantErr %v", err, ErrRuleNotFound)
+	}
+	if err := engine.RemoveRule("amountRule"); err != nil {
+		t.Fatalf("RemoveRule() gotErr %v", err)
+	}
+
+	if version := engine.Version(); version != 2 {
+		t.Errorf("Version() = %v, want 2", version)
+	}
+	wantRules := []string{"bothRule", "cityRule"}
+	if gotRules := testMatchedRules(t, engine, Input{"amount": "150", "city": "Delhi"}); !reflect.DeepEqual(gotRules, wantRules) {
+		t.Errorf("engine matched %v, want %v", gotRules, wantRules)
+	}
+}
+
+// returns names of matched rules in ascending priority order
+func testMatchedRules(t *testing.T, engine RuleEngine, input Input) []string {
+	outputs, err := engine.Evaluate(context.TODO(), input, EvaluateOptions().Complete())
+	if err != nil {
+		t.Fatalf("Evaluate() gotErr %v", err)
+	}
+	ret := []string{}
+	for _, output := range outputs {
+		ret = append(ret, output.Rulename)
+	}
+	return ret
+}
+
+func TestRuleEngine_Update_UnreferencedFields(t *testing.T) {
+	engine, err := NewUpdatable(testUpdateConfig())
+	if err != nil {
+		t.Fatalf("NewUpdatable() gotErr %v", err)
+	}
+	input := Input{"amount": "150"}
+	if _, err := engine.Evaluate(context.TODO(), input, EvaluateOptions().Complete()); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("Evaluate() gotErr %v, wantErr %v", err, ErrFieldNotFound)
+	}
+
+	// city is not referenced once rules having it are removed
+	for _, ruleName := range []string{"bothRule", "cityRule"} {
+		if engine, err = engine.RemoveRule(ruleName); err != nil {
+			t.Fatalf("RemoveRule() gotErr %v", err)
+		}
+	}
+	wantRules := []string{"amountRule"}
+	if gotRules := testMatchedRules(t, engine, input); !reflect.DeepEqual(gotRules, wantRules) {
+		t.Errorf("engine matched %v, want %v", gotRules, wantRules)
+	}
+
+	engine, err = engine.AddRule("cityRule", &RuleConfig{Priority: 3, RootCondition: &Condition{Type: "cityDelhi"}})
+	if err != nil {
+		t.Fatalf("AddRule() gotErr %v", err)
+	}
+	if _, err := engine.Evaluate(context.TODO(), input, EvaluateOptions().Complete()); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("Evaluate() gotErr %v, wantErr %v", err, ErrFieldNotFound)
+	}
+}
+
+func TestRuleEngine_Update_Index(t *testing.T) {
+	config := &RuleEngineConfig{
+		Fields:         Fields{"merchant": String, "amount": Integer, "weight": Float},
+		ConditionTypes: map[string]*ConditionType{},
+		Rules:          map[string]*RuleConfig{},
+	}
+	for i := 0; i < 4; i++ {
+		config.ConditionTypes["merchant"+strconv.Itoa(i)] = &ConditionType{Operator: EqualOperator, Operands: []*Operand{
+			{Type: Field, ValueType: String, Val: "merchant"},
+			{Type: Constant, ValueType: String, Val: "m" + strconv.Itoa(i)},
+		}}
+		config.ConditionTypes["amount"+strconv.Itoa(i)] = &ConditionType{Operator: LessOperator, Operands: []*Operand{
+			{Type: Field, ValueType: Integer, Val: "amount"},
+			{Type: Constant, ValueType: Integer, Val: strconv.Itoa(100 * (i + 1))},
+		}}
+		config.ConditionTypes["weight"+strconv.Itoa(i)] = &ConditionType{Operator: GreaterEqualOperator, Operands: []*Operand{
+			{Type: Field, ValueType: Float, Val: "weight"},
+			{Type: Constant, ValueType: Float, Val: strconv.Itoa(i) + ".5"},
+		}}
+	}
+
+	random := rand.New(rand.NewSource(1))
+	randomRule := func() *RuleConfig {
+		conditionType := []string{"merchant", "amount", "weight"}[random.Intn(3)] + strconv.Itoa(random.Intn(4))
+		root := &Condition{Type: conditionType}
+		switch random.Intn(3) {
+		case 1:
+			root = &Condition{Type: OrCondition, SubConditions: []*Condition{root, {Type: "merchant0"}}}
+		case 2:
+			root = &Condition{Type: AndCondition, SubConditions: []*Condition{root, {Type: "weight1"}}}
+		}
+		return &RuleConfig{Priority: random.Intn(20), RootCondition: root}
+	}
+	for i := 0; i < 20; i++ {
+		config.Rules["rule"+strconv.Itoa(i)] = randomRule()
+	}
+
+	engine, err := NewUpdatable(config, WithRuleIndex())
+	if err != nil {
+		t.Fatalf("NewUpdatable() gotErr %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		ruleName := "rule" + strconv.Itoa(random.Intn(30))
+		_, exist := config.Rules[ruleName]
+		switch {
+		case !exist:
+			config.Rules[ruleName] = randomRule()
+			engine, err = engine.AddRule(ruleName, config.Rules[ruleName])
+		case random.Intn(2) == 0:
+			config.Rules[ruleName] = randomRule()
+			engine, err = engine.ReplaceRule(ruleName, config.Rules[ruleName])
+		default:
+			delete(config.Rules, ruleName)
+			engine, err = engine.RemoveRule(ruleName)
+		}
+		if err != nil {
+			t.Fatalf("update %v gotErr %v", ruleName, err)
+		}
+		if i%10 == 0 {
+			conditionTypeName := "amount" + strconv.Itoa(random.Intn(4))
+			config.ConditionTypes[conditionTypeName] = &ConditionType{Operator: GreaterOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Integer, Val: "amount"},
+				{Type: Constant, ValueType: Integer, Val: strconv.Itoa(random.Intn(500))},
+			}}
+			if engine, err = engine.SetConditionType(conditionTypeName, config.ConditionTypes[conditionTypeName]); err != nil {
+				t.Fatalf("SetConditionType() gotErr %v", err)
+			}
+		}
+
+		// updated engine having patched index is same as engine built from the updated configuration
+		want, err := New(config)
+		if err != nil {
+			t.Fatalf("New() gotErr %v", err)
+		}
+		for j := 0; j < 10; j++ {
+			input := Input{
+				"merchant": "m" + strconv.Itoa(random.Intn(5)),
+				"amount":   strconv.Itoa(random.Intn(500)),
+				"weight":   strconv.Itoa(random.Intn(5)),
+			}
+			if gotRules, wantRules := testMatchedRules(t, engine, input), testMatchedRules(t, want, input); !reflect.DeepEqual(gotRules, wantRules) {
+				t.Fatalf("update %v input %v matched %v, want %v", i, input, gotRules, wantRules)
+			}
+		}
+	}
+}
+
+func TestRuleEngine_Update_PatchesIndex(t *testing.T) {
+	engine, err := NewUpdatable(testUpdateConfig(), WithRuleIndex())
+	if err != nil {
+		t.Fatalf("NewUpdatable() gotErr %v", err)
+	}
+	updated, err := engine.AddRule("newRule", &RuleConfig{Priority: 0, RootCondition: &Condition{Type: "amountMoreThan100"}})
+	if err != nil {
+		t.Fatalf("AddRule() gotErr %v", err)
+	}
+
+	// index of city is shared, as added rule does not expect city
+	index, updatedIndex := engine.(*ruleEngine).index, updated.(*ruleEngine).index
+	if len(index.fields) != 1 || len(updatedIndex.fields) != 1 || index.fields[0] != updatedIndex.fields[0] {
+		t.Errorf("AddRule() field index %v, want shared %v", updatedIndex.fields, index.fields)
+	}
+	if len(updatedIndex.integerRanges) != 1 || len(updatedIndex.integerRanges[0].tree.intervals) != 2 {
+		t.Errorf("AddRule() range index %v, want intervals of amountRule and newRule", updatedIndex.integerRanges)
+	}
+}
@@ -1,35 +1,200 @@
 package ruleenginecore
 
 import (
-	"fmt"
+	"errors"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func parseValue(value string, toType ValueType) (any, *RuleEngineError) {
+	return parseValueWithLayout(value, toType, time.RFC3339)
+}
+
+// parses value as per given valueType, 'dateTimeLayout' is considered as layout for DateTime valueType
+func parseValueWithLayout(value string, toType ValueType, dateTimeLayout string) (any, *RuleEngineError) {
 	switch toType {
 	case Boolean:
 		if val, err := strconv.ParseBool(value); err != nil {
-			return nil, newError(ErrCodeParsingFailed, fmt.Sprintf("ParingError: %v", err))
+			return nil, newError(ErrCodeParsingFailed).wrap(err)
 		} else {
 			return val, nil
 		}
 	case Integer:
 		if val, err := strconv.ParseInt(value, 10, 64); err != nil {
-			return nil, newError(ErrCodeParsingFailed, fmt.Sprintf("ParingError: %v", err))
+			return nil, newError(ErrCodeParsingFailed).wrap(err)
 		} else {
 			return val, nil
 		}
 	case Float:
 		if val, err := strconv.ParseFloat(value, 64); err != nil {
-			return nil, newError(ErrCodeParsingFailed, fmt.Sprintf("ParingError: %v", err))
+			return nil, newError(ErrCodeParsingFailed).wrap(err)
 		} else {
 			return val, nil
 		}
 	case String:
 		return value, nil
+	case DateTime:
+		if val, err := time.Parse(dateTimeLayout, value); err != nil {
+			return nil, newError(ErrCodeParsingFailed).wrap(err)
+		} else {
+			return val, nil
+		}
+	case Duration:
+		if val, err := parseDuration(value); err != nil {
+			return nil, newError(ErrCodeParsingFailed).wrap(err)
+		} else {
+			return val, nil
+		}
+
+	case BooleanList, StringList, IntegerList, FloatList:
+		return parseValueList(value, toType.elementType())
 
 	default:
 		// no-op
 		return nil, newError(ErrCodeInvalidValueType)
 	}
 }
+
+// leading days of duration string, having optional sign and fraction
+var durationDaysPattern = regexp.MustCompile(`^([-+]?)([0-9]+(?:\.[0-9]*)?|\.[0-9]+)d`)
+
+// parses duration string as supported by time.ParseDuration, additionally supports days as leading unit, which can be
+// fractional or followed by other units (ex. "30d", "-7d", "1.5d", "1d12h"), a day is considered as 24 hours
+func parseDuration(value string) (time.Duration, error) {
+	match := durationDaysPattern.FindStringSubmatch(value)
+	if match == nil {
+		return time.ParseDuration(value)
+	}
+	invalid := errors.New("time: invalid duration " + strconv.Quote(value))
+
+	days, _ := strconv.ParseFloat(match[2], 64)
+	if days > float64(math.MaxInt64)/float64(24*time.Hour) {
+		return 0, invalid
+	}
+	ret := time.Duration(math.Round(days * float64(24*time.Hour)))
+
+	if rest := value[len(match[0]):]; len(rest) != 0 {
+		// sign is expected only before days
+		if rest[0] == '-' || rest[0] == '+' {
+			return 0, invalid
+		}
+		restDuration, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, err
+		}
+		if ret += restDuration; ret < 0 {
+			return 0, invalid
+		}
+	}
+
+	if match[1] == "-" {
+		ret = -ret
+	}
+	return ret, nil
+}
+
+// parses comma separated values, every value is parsed as per given valueType
+func parseValueList(value string, toType ValueType) ([]any, *RuleEngineError) {
+	result := []any{}
+	if len(strings.TrimSpace(value)) == 0 {
+		return result, nil
+	}
+
+	for _, strVal := range strings.Split(value, ",") {
+		val, err := parseValue(strings.TrimSpace(strVal), toType)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, val)
+	}
+	return result, nil
+}
+
+// converts typed value to internal representation of given valueType, returns false if value is not of expected type
+func toTypedValue(value any, toType ValueType) (any, bool) {
+	switch toType {
+	case Boolean:
+		val, ok := value.(bool)
+		return val, ok
+	case String:
+		val, ok := value.(string)
+		return val, ok
+	case Integer:
+		switch val := value.(type) {
+		case int64:
+			return val, true
+		case int:
+			return int64(val), true
+		case int32:
+			return int64(val), true
+		case int16:
+			return int64(val), true
+		case int8:
+			return int64(val), true
+		case uint32:
+			return int64(val), true
+		case uint16:
+			return int64(val), true
+		case uint8:
+			return int64(val), true
+		}
+		return nil, false
+	case Float:
+		switch val := value.(type) {
+		case float64:
+			return val, true
+		case float32:
+			return float64(val), true
+		}
+		return nil, false
+	case DateTime:
+		val, ok := value.(time.Time)
+		return val, ok
+	case Duration:
+		val, ok := value.(time.Duration)
+		return val, ok
+	case BooleanList:
+		return toTypedList(value, Boolean)
+	case StringList:
+		return toTypedList(value, String)
+	case IntegerList:
+		return toTypedList(value, Integer)
+	case FloatList:
+		return toTypedList(value, Float)
+	}
+	return nil, false
+}
+
+// converts slice having elements of Go types expected for 'elementType' (see 'toTypedValue'), ex. []int or []any for
+// Integer elements
+func toTypedList(value any, elementType ValueType) (any, bool) {
+	values := reflect.ValueOf(value)
+	if values.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	result := make([]any, values.Len())
+	for i := range result {
+		typedVal, ok := toTypedValue(values.Index(i).Interface(), elementType)
+		if !ok {
+			return nil, false
+		}
+		result[i] = typedVal
+	}
+	return result, true
+}
+
+// returns keys of the map in ascending order
+func sortedKeys[V any](m map[string]V) []string {
+	ret := make([]string, 0, len(m))
+	for key := range m {
+		ret = append(ret, key)
+	}
+	sort.Strings(ret)
+	return ret
+}
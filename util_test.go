@@ -3,6 +3,7 @@ package ruleenginecore
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_parseValue(t *testing.T) {
@@ -97,6 +98,132 @@ func Test_parseValue(t *testing.T) {
 			want:    "asdf",
 			wantErr: nil,
 		},
+		{
+			name: "valid_dateTime",
+			args: args{
+				value:  "2023-03-14T10:30:00Z",
+				toType: DateTime,
+			},
+			want:    time.Date(2023, 3, 14, 10, 30, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name: "invalid_dateTime",
+			args: args{
+				value:  "14-03-2023",
+				toType: DateTime,
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+		{
+			name: "valid_duration",
+			args: args{
+				value:  "1h30m",
+				toType: Duration,
+			},
+			want:    90 * time.Minute,
+			wantErr: nil,
+		},
+		{
+			name: "valid_durationDays",
+			args: args{
+				value:  "-30d",
+				toType: Duration,
+			},
+			want:    -30 * 24 * time.Hour,
+			wantErr: nil,
+		},
+		{
+			name: "valid_durationFractionalDays",
+			args: args{
+				value:  "1.5d",
+				toType: Duration,
+			},
+			want:    36 * time.Hour,
+			wantErr: nil,
+		},
+		{
+			name: "valid_durationCompoundDays",
+			args: args{
+				value:  "-1d12h30m",
+				toType: Duration,
+			},
+			want:    -(36*time.Hour + 30*time.Minute),
+			wantErr: nil,
+		},
+		{
+			name: "valid_durationDaysWithoutWhole",
+			args: args{
+				value:  ".5d",
+				toType: Duration,
+			},
+			want:    12 * time.Hour,
+			wantErr: nil,
+		},
+		{
+			name: "invalid_durationDaysAfterHours",
+			args: args{
+				value:  "12h1d",
+				toType: Duration,
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+		{
+			name: "invalid_durationSignAfterDays",
+			args: args{
+				value:  "1d-12h",
+				toType: Duration,
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+		{
+			name: "invalid_durationDaysOverflow",
+			args: args{
+				value:  "200000d",
+				toType: Duration,
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+		{
+			name: "invalid_durationCompoundOverflow",
+			args: args{
+				value:  "106751d24h",
+				toType: Duration,
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+		{
+			name: "invalid_duration",
+			args: args{
+				value:  "30days",
+				toType: Duration,
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+		{
+			name: "valid_stringList",
+			args: args{
+				value:  "books, electronics",
+				toType: StringList,
+			},
+			want:    []any{"books", "electronics"},
+			wantErr: nil,
+		},
+		{
+			name: "invalid_integerList",
+			args: args{
+				value:  "1,two",
+				toType: IntegerList,
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
 		{
 			name: "invalid_valueType",
 			args: args{
@@ -122,3 +249,174 @@ func Test_parseValue(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseValueList(t *testing.T) {
+	type args struct {
+		value  string
+		toType ValueType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []any
+		wantErr *RuleEngineError
+	}{
+		{
+			name: "valid_string",
+			args: args{
+				value:  "Bangalore, Delhi ,Mumbai",
+				toType: String,
+			},
+			want:    []any{"Bangalore", "Delhi", "Mumbai"},
+			wantErr: nil,
+		},
+		{
+			name: "valid_Int",
+			args: args{
+				value:  "1,-2,3",
+				toType: Integer,
+			},
+			want:    []any{int64(1), int64(-2), int64(3)},
+			wantErr: nil,
+		},
+		{
+			name: "valid_empty",
+			args: args{
+				value:  " ",
+				toType: Integer,
+			},
+			want:    []any{},
+			wantErr: nil,
+		},
+		{
+			name: "invalid_Int",
+			args: args{
+				value:  "1,two,3",
+				toType: Integer,
+			},
+			want:    nil,
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := parseValueList(tt.args.value, tt.args.toType)
+
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseValueList() = %v, want %v", got, tt.want)
+			}
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("parseValueList() gotErr %v, wantErr %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_toTypedValue(t *testing.T) {
+	type args struct {
+		value  any
+		toType ValueType
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   any
+		wantOk bool
+	}{
+		{
+			name:   "valid_boolean",
+			args:   args{value: true, toType: Boolean},
+			want:   true,
+			wantOk: true,
+		},
+		{
+			name:   "valid_Int",
+			args:   args{value: 10, toType: Integer},
+			want:   int64(10),
+			wantOk: true,
+		},
+		{
+			name:   "valid_float32",
+			args:   args{value: float32(1.5), toType: Float},
+			want:   float64(1.5),
+			wantOk: true,
+		},
+		{
+			name:   "valid_duration",
+			args:   args{value: time.Hour, toType: Duration},
+			want:   time.Hour,
+			wantOk: true,
+		},
+		{
+			name:   "valid_typedList",
+			args:   args{value: []int64{1, 2}, toType: IntegerList},
+			want:   []any{int64(1), int64(2)},
+			wantOk: true,
+		},
+		{
+			name:   "valid_intList",
+			args:   args{value: []int{1, 2}, toType: IntegerList},
+			want:   []any{int64(1), int64(2)},
+			wantOk: true,
+		},
+		{
+			name:   "valid_int32List",
+			args:   args{value: []int32{1, 2}, toType: IntegerList},
+			want:   []any{int64(1), int64(2)},
+			wantOk: true,
+		},
+		{
+			name:   "valid_float32List",
+			args:   args{value: []float32{1.5}, toType: FloatList},
+			want:   []any{float64(1.5)},
+			wantOk: true,
+		},
+		{
+			name:   "valid_anyList",
+			args:   args{value: []any{1, int64(2)}, toType: IntegerList},
+			want:   []any{int64(1), int64(2)},
+			wantOk: true,
+		},
+		{
+			name:   "invalid_Int",
+			args:   args{value: "10", toType: Integer},
+			wantOk: false,
+		},
+		{
+			name:   "invalid_uint64",
+			args:   args{value: uint64(10), toType: Integer},
+			wantOk: false,
+		},
+		{
+			name:   "invalid_uint64List",
+			args:   args{value: []uint64{1}, toType: IntegerList},
+			wantOk: false,
+		},
+		{
+			name:   "invalid_notList",
+			args:   args{value: int64(1), toType: IntegerList},
+			wantOk: false,
+		},
+		{
+			name:   "invalid_anyList",
+			args:   args{value: []any{"1"}, toType: IntegerList},
+			wantOk: false,
+		},
+		{
+			name:   "invalid_valueType",
+			args:   args{value: "invalid", toType: ValueType(0)},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := toTypedValue(tt.args.value, tt.args.toType)
+			if gotOk != tt.wantOk {
+				t.Errorf("toTypedValue() gotOk = %v, wantOk %v", gotOk, tt.wantOk)
+			}
+			if tt.wantOk && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toTypedValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
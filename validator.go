@@ -2,6 +2,10 @@ package ruleenginecore
 
 import (
 	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
 )
 
 type fieldValidatorFunc func(fs Fields) *RuleEngineError
@@ -21,6 +25,53 @@ var fieldValueTypeValidator = func() fieldValidatorFunc {
 	}
 }
 
+var fieldNameValidator = func() fieldValidatorFunc {
+	return func(fs Fields) *RuleEngineError {
+		for fieldName := range fs {
+			if len(fieldName) == 0 || strings.HasPrefix(fieldName, reservedFieldPrefix) {
+				return newError(ErrCodeInvalidFieldName,
+					fmt.Sprintf("field: '%v'", fieldName),
+					fmt.Sprintf("field name should not be empty or start with '%v'.", reservedFieldPrefix))
+			}
+		}
+		return nil
+	}
+}
+
+// validates field options, DateTime default values are parsed with 'dateTimeLayout'
+func validateFieldOptions(fs Fields, fieldOptions map[string]*FieldOption, dateTimeLayout string) *RuleEngineError {
+	for _, fieldName := range sortedKeys(fieldOptions) {
+		if err := validateFieldOption(fs, fieldName, fieldOptions[fieldName], dateTimeLayout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateFieldOption(fs Fields, fieldName string, option *FieldOption, dateTimeLayout string) *RuleEngineError {
+	fieldType, ok := fs[fieldName]
+	if !ok {
+		return newError(ErrCodeFieldNotFound,
+			fmt.Sprintf("FieldOption defined for unknown field: %v", fieldName))
+	}
+
+	if option == nil || (option.Default == nil && !option.Nullable) || (option.Default != nil && option.Nullable) {
+		return newError(ErrCodeInvalidFieldOption,
+			fmt.Sprintf("field: %v, expecting either default value or nullable", fieldName))
+	}
+
+	if option.Default != nil {
+		typedDefault, err := parseValueWithLayout(*option.Default, fieldType, dateTimeLayout)
+		if err != nil {
+			err.addMsg(fmt.Sprintf("Default value: %v of field: %v failed to parse as ValueType: %v",
+				*option.Default, fieldName, fieldType))
+			return err
+		}
+		option.typedDefault = typedDefault
+	}
+	return nil
+}
+
 var operandCountValidator = func(count int) conditionTypeValidatorFunc {
 	return func(ct *ConditionType, fs Fields) *RuleEngineError {
 		if len(ct.Operands) != count {
@@ -73,6 +124,17 @@ var operandValueTypeValidator = func(supportedValueTypes ...ValueType) condition
 	}
 }
 
+var operandAtIndexValueTypeValidator = func(index int, supportedValueTypes ...ValueType) conditionTypeValidatorFunc {
+	supportedTypeValidator := operandValueTypeValidator(supportedValueTypes...)
+	return func(ct *ConditionType, fs Fields) *RuleEngineError {
+		if err := supportedTypeValidator(&ConditionType{Operands: ct.Operands[index : index+1]}, fs); err != nil {
+			err.addMsg(fmt.Sprintf("Operand at index: %v", index))
+			return err
+		}
+		return nil
+	}
+}
+
 var operandValidator = func() conditionTypeValidatorFunc {
 	return func(ct *ConditionType, fs Fields) *RuleEngineError {
 		for _, op := range ct.Operands {
@@ -84,6 +146,101 @@ var operandValidator = func() conditionTypeValidatorFunc {
 	}
 }
 
+var operandAtIndexValidator = func(index int) conditionTypeValidatorFunc {
+	return func(ct *ConditionType, fs Fields) *RuleEngineError {
+		return validateAndParseOperand(ct.Operands[index], fs)
+	}
+}
+
+var fieldOperandValidator = func(index int) conditionTypeValidatorFunc {
+	return func(ct *ConditionType, fs Fields) *RuleEngineError {
+		if !ct.Operands[index].isField() {
+			return newError(ErrCodeInvalidOperandType,
+				fmt.Sprintf("Expecting operand at index %v as %v operandType", index, Field))
+		}
+		return nil
+	}
+}
+
+var constantOperandValidator = func(index int) conditionTypeValidatorFunc {
+	return func(ct *ConditionType, fs Fields) *RuleEngineError {
+		if ct.Operands[index].Type != Constant {
+			return newError(ErrCodeInvalidOperandType,
+				fmt.Sprintf("Expecting operand at index %v as %v operandType", index, Constant))
+		}
+		return nil
+	}
+}
+
+// compiles constant operand value as regular expression, expects operand at index to be parsed already by operandValidator
+var patternOperandValidator = func(index int) conditionTypeValidatorFunc {
+	return func(ct *ConditionType, fs Fields) *RuleEngineError {
+		operand := ct.Operands[index]
+		pattern, err := regexp.Compile(operand.Val)
+		if err != nil {
+			return newError(ErrCodeParsingFailed,
+				fmt.Sprintf("Constant operand with value: %v is not a valid regular expression", operand.Val)).wrap(err)
+		}
+
+		operand.typedValue = pattern
+		return nil
+	}
+}
+
+// parses constant operand value as comma separated list of values into a set, operand value type is considered as
+// value type of every value
+var setOperandValidator = func(index int) conditionTypeValidatorFunc {
+	return func(ct *ConditionType, fs Fields) *RuleEngineError {
+		operand := ct.Operands[index]
+		if !operand.ValueType.isValid() {
+			return newError(ErrCodeInvalidValueType, fmt.Sprintf("Invalid ValueType. Supported value types %v", valueTypeList))
+		}
+
+		values, err := parseValueList(operand.Val, operand.ValueType)
+		if err != nil {
+			err.addMsg(fmt.Sprintf("Constant operand with value: %v failed to parse as list of ValueType: %v",
+				operand.Val, operand.ValueType))
+			return err
+		}
+		if isNaNValue(values) {
+			return newError(ErrCodeInvalidOperand,
+				fmt.Sprintf("Constant operand with value: %v has a value, which is not a number", operand.Val))
+		}
+
+		valueSet := NewSet[any]()
+		for _, val := range values {
+			valueSet.Add(val)
+		}
+
+		operand.typedValue, operand.typedValues = valueSet, values
+		return nil
+	}
+}
+
+// maintains constant list operand value as a set, expects operand at index to be parsed already by operandValidator
+var listSetOperandValidator = func(index int) conditionTypeValidatorFunc {
+	return func(ct *ConditionType, fs Fields) *RuleEngineError {
+		operand := ct.Operands[index]
+		if operand.Type != Constant {
+			return nil
+		}
+
+		values, ok := operand.typedValue.([]any)
+		if !ok {
+			return newError(ErrCodeInvalidOperand,
+				fmt.Sprintf("Expecting list value for operand at index %v", index))
+		}
+
+		valueSet := NewSet[any]()
+		for _, val := range values {
+			valueSet.Add(val)
+		}
+
+		operand.typedValue, operand.typedValues = valueSet, values
+		return nil
+	}
+}
+
 func validateAndParseOperand(operand *Operand, fs Fields) *RuleEngineError {
 
 	if !operand.Type.isValid() {
@@ -98,11 +255,31 @@ func validateAndParseOperand(operand *Operand, fs Fields) *RuleEngineError {
 	if operand.isField() {
 		if !fs.exist(operand.Val, operand.ValueType) {
 			return newError(ErrCodeFieldNotFound,
-				fmt.Sprintf("Expecting field: %v with valueType: %v", operand.Val, operand.ValueType))
+				fmt.Sprintf("Expecting field: %v with valueType: %v", operand.Val, operand.ValueType)).withField(operand.Val)
 		}
 		return nil
 	}
 
+	// Now operandType
+	if operand.Type == Now {
+		if operand.ValueType != DateTime {
+			return newError(ErrCodeInvalidOperand,
+				fmt.Sprintf("Expecting valueType: %v for operandType: %v", DateTime, Now))
+		}
+
+		offset := time.Duration(0)
+		if len(operand.Val) != 0 {
+			var err error
+			if offset, err = parseDuration(operand.Val); err != nil {
+				return newError(ErrCodeParsingFailed,
+					fmt.Sprintf("Now operand with value: %v failed to parse as Duration", operand.Val)).wrap(err)
+			}
+		}
+
+		operand.typedValue = offset
+		return nil
+	}
+
 	// Constant operandType
 	typedValue, err := parseValue(operand.Val, operand.ValueType)
 	if err != nil {
@@ -111,10 +288,30 @@ func validateAndParseOperand(operand *Operand, fs Fields) *RuleEngineError {
 		return err
 	}
 
+	if isNaNValue(typedValue) {
+		return newError(ErrCodeInvalidOperand,
+			fmt.Sprintf("Constant operand with value: %v is not a number, which can not be compared", operand.Val))
+	}
+
 	operand.typedValue = typedValue
 	return nil
 }
 
+// reports whether value is NaN or a list having NaN, NaN is neither equal to nor ordered with any value
+func isNaNValue(val any) bool {
+	switch v := val.(type) {
+	case float64:
+		return math.IsNaN(v)
+	case []any:
+		for _, element := range v {
+			if isNaNValue(element) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 var subConditionCountRuleConditionValidator = func(count int) ruleConditionValidatorFunc {
 	return func(c *Condition) *RuleEngineError {
 		if len(c.SubConditions) != count {
@@ -137,6 +334,10 @@ type ruleEngineConfigValidator struct {
 	fieldValidators         []fieldValidatorFunc
 	condTypeValidators      map[string][]conditionTypeValidatorFunc
 	ruleConditionValidators map[string][]ruleConditionValidatorFunc
+
+	// reports whether operator is known, condition type having unknown operator is invalid. Operators are not
+	// checked if nil.
+	operatorExist func(operator string) bool
 }
 
 func (v *ruleEngineConfigValidator) addFieldValidator(validators ...fieldValidatorFunc) {
@@ -147,11 +348,19 @@ func (v *ruleEngineConfigValidator) addConditionTypeValidator(operator string, v
 	v.condTypeValidators[operator] = validators
 }
 
+// adds validators after the existing validators of the operator
+func (v *ruleEngineConfigValidator) appendConditionTypeValidator(operator string, validators ...conditionTypeValidatorFunc) {
+	v.condTypeValidators[operator] = append(v.condTypeValidators[operator], validators...)
+}
+
 func (v *ruleEngineConfigValidator) addRuleConditionValidator(operator string, validators ...ruleConditionValidatorFunc) {
 	v.ruleConditionValidators[operator] = validators
 }
 
 func (v *ruleEngineConfigValidator) validateConditionType(ct *ConditionType, fs Fields) *RuleEngineError {
+	if v.operatorExist != nil && !v.operatorExist(ct.Operator) {
+		return newError(ErrCodeInvalidOperator, fmt.Sprintf("Operator: %v", ct.Operator))
+	}
 	validatorFuncs := v.condTypeValidators[ct.Operator]
 
 	for _, validatorFunc := range validatorFuncs {
@@ -163,6 +372,9 @@ func (v *ruleEngineConfigValidator) validateConditionType(ct *ConditionType, fs
 }
 
 func (v *ruleEngineConfigValidator) validateRule(rc *RuleConfig) *RuleEngineError {
+	if rc == nil || rc.RootCondition == nil {
+		return newError(ErrCodeInvalidConditionType, "condition is not defined")
+	}
 	return validateRuleCondition(v, rc.RootCondition)
 }
 
@@ -189,76 +401,129 @@ func validateRuleCondition(v *ruleEngineConfigValidator, c *Condition) *RuleEngi
 	return nil
 }
 
-func (v *ruleEngineConfigValidator) validate(config *RuleEngineConfig) *RuleEngineError {
-	for _, fieldValidator := range v.fieldValidators {
-		if err := fieldValidator(config.Fields); err != nil {
-			return err
+// validates the config, reports first problem as per 'validateAll' order
+func (v *ruleEngineConfigValidator) validate(config *RuleEngineConfig, dateTimeLayout string) *RuleEngineError {
+	if errs := v.validateAll(config, dateTimeLayout); len(errs) != 0 {
+		return errs[0].Err
+	}
+	return nil
+}
+
+// validates the config and reports every problem found, problems are ordered as fields, field options, condition
+// types and rules, each ordered by name. DateTime default values of field options are parsed with 'dateTimeLayout'.
+func (v *ruleEngineConfigValidator) validateAll(config *RuleEngineConfig, dateTimeLayout string) []*ValidationError {
+	ret := []*ValidationError{}
+
+	for _, fieldName := range sortedKeys(config.Fields) {
+		for _, fieldValidator := range v.fieldValidators {
+			if err := fieldValidator(Fields{fieldName: config.Fields[fieldName]}); err != nil {
+				ret = append(ret, newValidationError(err.withField(fieldName), configPath("fields", fieldName)))
+				break
+			}
 		}
 	}
 
-	for conditionTypeName, conditionType := range config.ConditionTypes {
-		if err := v.validateConditionType(conditionType, config.Fields); err != nil {
-			err.addMsg(fmt.Sprintf("ConditionType: %v", conditionTypeName))
-			return err
+	for _, fieldName := range sortedKeys(config.FieldOptions) {
+		if err := validateFieldOption(config.Fields, fieldName, config.FieldOptions[fieldName], dateTimeLayout); err != nil {
+			ret = append(ret, newValidationError(err.withField(fieldName), configPath("fieldOptions", fieldName)))
 		}
 	}
 
-	for ruleName, rc := range config.Rules {
-		if err := v.validateRule(rc); err != nil {
-			err.addMsg(fmt.Sprintf("RuleName: %v", ruleName))
-			return err
+	for _, conditionTypeName := range sortedKeys(config.ConditionTypes) {
+		if err := v.validateConditionType(config.ConditionTypes[conditionTypeName], config.Fields); err != nil {
+			ret = append(ret, newValidationError(err.withConditionType(conditionTypeName),
+				configPath("conditionTypes", conditionTypeName)))
 		}
 	}
 
-	return nil
+	for _, ruleName := range sortedKeys(config.Rules) {
+		rc := config.Rules[ruleName]
+		if rc == nil || rc.RootCondition == nil {
+			err := newError(ErrCodeInvalidConditionType, "condition is not defined").withRule(ruleName)
+			ret = append(ret, newValidationError(err, configPath("rules", ruleName, "condition")))
+			continue
+		}
+
+		for _, problem := range v.validateAllRuleCondition(rc.RootCondition, config.ConditionTypes,
+			configPath("rules", ruleName, "condition")) {
+			problem.Err.withRule(ruleName)
+			ret = append(ret, problem)
+		}
+	}
+
+	return ret
 }
 
-var engineConfigValidator = ruleEngineConfigValidator{
-	fieldValidators:         []fieldValidatorFunc{},
-	condTypeValidators:      make(map[string][]conditionTypeValidatorFunc),
-	ruleConditionValidators: make(map[string][]ruleConditionValidatorFunc),
+// recursive validation for Rule Condition, reports every problem of the condition tree in depth first order
+func (v *ruleEngineConfigValidator) validateAllRuleCondition(c *Condition, conditionTypes map[string]*ConditionType,
+	path string) []*ValidationError {
+	ret := []*ValidationError{}
+
+	if validatorFuncs, ok := v.ruleConditionValidators[c.Type]; ok {
+		for _, validatorFunc := range validatorFuncs {
+			if err := validatorFunc(c); err != nil {
+				ret = append(ret, &ValidationError{Path: path, Err: err})
+				break
+			}
+		}
+	} else if _, ok := conditionTypes[c.Type]; !ok {
+		ret = append(ret, &ValidationError{Path: path, Err: newError(ErrCodeConditionTypeNotFound).withConditionType(c.Type)})
+	}
+
+	for i, subCond := range c.SubConditions {
+		// recursion
+		ret = append(ret, v.validateAllRuleCondition(subCond, conditionTypes, fmt.Sprintf("%v.subConditions[%v]", path, i))...)
+	}
+
+	return ret
 }
 
-func init() {
-	engineConfigValidator.addFieldValidator(fieldValueTypeValidator())
+func newRuleEngineConfigValidator() *ruleEngineConfigValidator {
+	engineConfigValidator := &ruleEngineConfigValidator{
+		fieldValidators:         []fieldValidatorFunc{},
+		condTypeValidators:      make(map[string][]conditionTypeValidatorFunc),
+		ruleConditionValidators: make(map[string][]ruleConditionValidatorFunc),
+	}
+
+	engineConfigValidator.addFieldValidator(fieldNameValidator(), fieldValueTypeValidator())
 
 	engineConfigValidator.addConditionTypeValidator(EqualOperator,
 		operandCountValidator(2),
 		operandsWithSameValueTypeValidator(),
-		operandValueTypeValidator(Integer, Float, Boolean, String),
+		operandValueTypeValidator(Integer, Float, Boolean, String, DateTime, Duration),
 		operandValidator(),
 	)
 	engineConfigValidator.addConditionTypeValidator(NotEqualOperator,
 		operandCountValidator(2),
 		operandsWithSameValueTypeValidator(),
-		operandValueTypeValidator(Integer, Float, Boolean, String),
+		operandValueTypeValidator(Integer, Float, Boolean, String, DateTime, Duration),
 		operandValidator(),
 	)
 
 	engineConfigValidator.addConditionTypeValidator(GreaterOperator,
 		operandCountValidator(2),
 		operandsWithSameValueTypeValidator(),
-		operandValueTypeValidator(Integer, Float),
+		operandValueTypeValidator(Integer, Float, DateTime, Duration),
 		operandValidator(),
 	)
 
 	engineConfigValidator.addConditionTypeValidator(GreaterEqualOperator,
 		operandCountValidator(2),
 		operandsWithSameValueTypeValidator(),
-		operandValueTypeValidator(Integer, Float),
+		operandValueTypeValidator(Integer, Float, DateTime, Duration),
 		operandValidator(),
 	)
 
 	engineConfigValidator.addConditionTypeValidator(LessOperator,
 		operandCountValidator(2),
 		operandsWithSameValueTypeValidator(),
-		operandValueTypeValidator(Integer, Float),
+		operandValueTypeValidator(Integer, Float, DateTime, Duration),
 		operandValidator(),
 	)
 	engineConfigValidator.addConditionTypeValidator(LessEqualOperator,
 		operandCountValidator(2),
 		operandsWithSameValueTypeValidator(),
-		operandValueTypeValidator(Integer, Float),
+		operandValueTypeValidator(Integer, Float, DateTime, Duration),
 		operandValidator(),
 	)
 
@@ -269,6 +534,61 @@ func init() {
 		operandValidator(),
 	)
 
+	engineConfigValidator.addConditionTypeValidator(MatchOperator,
+		operandCountValidator(2),
+		operandsWithSameValueTypeValidator(),
+		operandValueTypeValidator(String),
+		constantOperandValidator(secondOperand),
+		operandValidator(),
+		patternOperandValidator(secondOperand),
+	)
+
+	engineConfigValidator.addConditionTypeValidator(InOperator,
+		operandCountValidator(2),
+		operandsWithSameValueTypeValidator(),
+		operandValueTypeValidator(Integer, Float, Boolean, String),
+		constantOperandValidator(secondOperand),
+		operandAtIndexValidator(firstOperand),
+		setOperandValidator(secondOperand),
+	)
+
+	engineConfigValidator.addConditionTypeValidator(NotInOperator,
+		operandCountValidator(2),
+		operandsWithSameValueTypeValidator(),
+		operandValueTypeValidator(Integer, Float, Boolean, String),
+		constantOperandValidator(secondOperand),
+		operandAtIndexValidator(firstOperand),
+		setOperandValidator(secondOperand),
+	)
+
+	for _, listOperator := range []string{AnyOfOperator, AllOfOperator, NoneOfOperator} {
+		engineConfigValidator.addConditionTypeValidator(listOperator,
+			operandCountValidator(2),
+			operandsWithSameValueTypeValidator(),
+			operandValueTypeValidator(BooleanList, StringList, IntegerList, FloatList),
+			operandValidator(),
+			listSetOperandValidator(secondOperand),
+		)
+	}
+
+	for _, sizeOperator := range []string{SizeEqualOperator, SizeNotEqualOperator, SizeGreaterOperator,
+		SizeGreaterEqualOperator, SizeLessOperator, SizeLessEqualOperator} {
+		engineConfigValidator.addConditionTypeValidator(sizeOperator,
+			operandCountValidator(2),
+			operandAtIndexValueTypeValidator(firstOperand, BooleanList, StringList, IntegerList, FloatList),
+			operandAtIndexValueTypeValidator(secondOperand, Integer),
+			operandValidator(),
+		)
+	}
+
+	for _, presenceOperator := range []string{IsPresentOperator, IsMissingOperator} {
+		engineConfigValidator.addConditionTypeValidator(presenceOperator,
+			operandCountValidator(1),
+			fieldOperandValidator(firstOperand),
+			operandValidator(),
+		)
+	}
+
 	engineConfigValidator.addRuleConditionValidator(OrCondition,
 		minSubConditionCountRuleConditionValidator(2))
 
@@ -278,4 +598,5 @@ func init() {
 	engineConfigValidator.addRuleConditionValidator(NegationCondition,
 		subConditionCountRuleConditionValidator(1))
 
+	return engineConfigValidator
 }
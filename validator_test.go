@@ -1,19 +1,24 @@
 package ruleenginecore
 
 import (
+	"errors"
+	"reflect"
+	"regexp"
 	"testing"
+	"time"
 )
 
-func isErrorEqual(err1, err2 *RuleEngineError) bool {
-	if err1 == nil && err2 == nil {
-		return true
+// reports whether err is nil same as wantErr, or err has same error code as wantErr
+func isErrorEqual(err error, wantErr *RuleEngineError) bool {
+	var gotErr *RuleEngineError
+	if err != nil && !errors.As(err, &gotErr) {
+		return false
 	}
 
-	if err1 != nil && err2 != nil && err1.ErrCode == err2.ErrCode {
-		return true
+	if gotErr == nil || wantErr == nil {
+		return gotErr == nil && wantErr == nil
 	}
-
-	return false
+	return errors.Is(gotErr, wantErr)
 }
 
 func Test_fieldValueTypeValidator(t *testing.T) {
@@ -49,6 +54,36 @@ func Test_fieldValueTypeValidator(t *testing.T) {
 			validatorFunc: fieldValueTypeValidator(),
 			wantErr:       newError(ErrCodeInvalidValueType),
 		},
+		{
+			name: "valid_FieldName",
+			args: args{
+				fs: Fields{
+					"createdAt": DateTime,
+				},
+			},
+			validatorFunc: fieldNameValidator(),
+			wantErr:       nil,
+		},
+		{
+			name: "invalid_ReservedFieldName",
+			args: args{
+				fs: Fields{
+					"$now": DateTime,
+				},
+			},
+			validatorFunc: fieldNameValidator(),
+			wantErr:       newError(ErrCodeInvalidFieldName),
+		},
+		{
+			name: "invalid_EmptyFieldName",
+			args: args{
+				fs: Fields{
+					"": String,
+				},
+			},
+			validatorFunc: fieldNameValidator(),
+			wantErr:       newError(ErrCodeInvalidFieldName),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -329,6 +364,62 @@ func Test_operandValidator(t *testing.T) {
 			validatorFunc: operandValidator(),
 			wantErr:       newError(ErrCodeFieldNotFound),
 		},
+		{
+			name: "valid_NowOperand",
+			args: args{
+				fs: Fields{
+					"createdAt": DateTime,
+				},
+				ct: &ConditionType{
+					Operator: GreaterOperator,
+					Operands: []*Operand{
+						{
+							Type:      Field,
+							ValueType: DateTime,
+							Val:       "createdAt",
+						},
+						{
+							Type:      Now,
+							ValueType: DateTime,
+							Val:       "-30d",
+						},
+					},
+				},
+			},
+			validatorFunc: operandValidator(),
+			wantErr:       nil,
+		},
+		{
+			name: "invalid_NowOperandValueType",
+			args: args{
+				ct: &ConditionType{
+					Operands: []*Operand{
+						{
+							Type:      Now,
+							ValueType: Integer,
+						},
+					},
+				},
+			},
+			validatorFunc: operandValidator(),
+			wantErr:       newError(ErrCodeInvalidOperand),
+		},
+		{
+			name: "invalid_NowOperandOffset",
+			args: args{
+				ct: &ConditionType{
+					Operands: []*Operand{
+						{
+							Type:      Now,
+							ValueType: DateTime,
+							Val:       "30days",
+						},
+					},
+				},
+			},
+			validatorFunc: operandValidator(),
+			wantErr:       newError(ErrCodeParsingFailed),
+		},
 		{
 			name: "invalid_ConstantValueParsingFailed",
 			args: args{
@@ -345,6 +436,38 @@ func Test_operandValidator(t *testing.T) {
 			validatorFunc: operandValidator(),
 			wantErr:       newError(ErrCodeParsingFailed),
 		},
+		{
+			name: "invalid_ConstantValueNotANumber",
+			args: args{
+				ct: &ConditionType{
+					Operands: []*Operand{
+						{
+							Type:      Constant,
+							ValueType: Float,
+							Val:       "NaN",
+						},
+					},
+				},
+			},
+			validatorFunc: operandValidator(),
+			wantErr:       newError(ErrCodeInvalidOperand),
+		},
+		{
+			name: "invalid_ConstantListValueNotANumber",
+			args: args{
+				ct: &ConditionType{
+					Operands: []*Operand{
+						{
+							Type:      Constant,
+							ValueType: FloatList,
+							Val:       "1.5,NaN",
+						},
+					},
+				},
+			},
+			validatorFunc: operandValidator(),
+			wantErr:       newError(ErrCodeInvalidOperand),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -500,7 +623,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_fields",
 			validators: testValidators{
-				fieldValidators: engineConfigValidator.fieldValidators,
+				fieldValidators: builtinValidator.fieldValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -517,7 +640,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "invalid_fields",
 			validators: testValidators{
-				fieldValidators: engineConfigValidator.fieldValidators,
+				fieldValidators: builtinValidator.fieldValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -534,7 +657,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_EqualOperatorBasedConditionType_Integer",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -565,7 +688,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_EqualOperatorBasedConditionType_Float",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -596,7 +719,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_EqualOperatorBasedConditionType_Boolean",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -627,7 +750,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_EqualOperatorBasedConditionType_String",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -658,7 +781,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "invalid_EqualOperatorBasedConditionType_InvalidOperandValueType",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -690,7 +813,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_NotEqualOperatorBasedConditionType_Integer",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -721,7 +844,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_NotEqualOperatorBasedConditionType_Float",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -752,7 +875,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_NotEqualOperatorBasedConditionType_Boolean",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -783,7 +906,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_NotEqualOperatorBasedConditionType_String",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -814,7 +937,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "invalid_NotEqualOperatorBasedConditionType_InvalidOperandValueType",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -846,7 +969,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_GreaterOperatorBasedConditionType_Integer",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -877,7 +1000,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_GreaterOperatorBasedConditionType_Float",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -908,7 +1031,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "invalid_GreaterOperatorBasedConditionType_InvalidOperandValueType",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -940,7 +1063,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_GreaterEqualOperatorBasedConditionType_Integer",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -971,7 +1094,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_GreaterEqualOperatorBasedConditionType_Float",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1002,7 +1125,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "invalid_GreaterEqualOperatorBasedConditionType_InvalidOperandValueType",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1034,7 +1157,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_LessOperatorBasedConditionType_Integer",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1065,7 +1188,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_LessOperatorBasedConditionType_Float",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1096,7 +1219,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "invalid_LessOperatorBasedConditionType_InvalidOperandValueType",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1128,7 +1251,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_LessEqualOperatorBasedConditionType_Integer",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1159,7 +1282,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_LessEqualOperatorBasedConditionType_Float",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1190,7 +1313,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "invalid_LessEqualOperatorBasedConditionType_InvalidOperandValueType",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1222,7 +1345,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_ContainOperatorBasedConditionType_Integer",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1253,7 +1376,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "invalid_ContainOperatorBasedConditionType_InvalidOperandValueType",
 			validators: testValidators{
-				condTypeValidators: engineConfigValidator.condTypeValidators,
+				condTypeValidators: builtinValidator.condTypeValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1285,7 +1408,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_RuleCondition_singleLevel",
 			validators: testValidators{
-				ruleConditionValidators: engineConfigValidator.ruleConditionValidators,
+				ruleConditionValidators: builtinValidator.ruleConditionValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1317,7 +1440,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "valid_RuleCondition_multiLevel",
 			validators: testValidators{
-				ruleConditionValidators: engineConfigValidator.ruleConditionValidators,
+				ruleConditionValidators: builtinValidator.ruleConditionValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1357,7 +1480,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "invalid_RuleCondition_singleLevel",
 			validators: testValidators{
-				ruleConditionValidators: engineConfigValidator.ruleConditionValidators,
+				ruleConditionValidators: builtinValidator.ruleConditionValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1386,7 +1509,7 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 		{
 			name: "invalid_RuleCondition_multiLevel",
 			validators: testValidators{
-				ruleConditionValidators: engineConfigValidator.ruleConditionValidators,
+				ruleConditionValidators: builtinValidator.ruleConditionValidators,
 			},
 			args: args{
 				config: &RuleEngineConfig{
@@ -1430,9 +1553,396 @@ func Test_ruleEngineConfigValidator_validate(t *testing.T) {
 				ruleConditionValidators: tt.validators.ruleConditionValidators,
 			}
 
-			if gotErr := v.validate(tt.args.config); !isErrorEqual(gotErr, tt.wantErr) {
+			if gotErr := v.validate(tt.args.config, time.RFC3339); !isErrorEqual(gotErr, tt.wantErr) {
 				t.Errorf("ruleEngineConfigValidator.validate() gotErr %v, wantErr %v", gotErr, tt.wantErr)
 			}
 		})
 	}
 }
+
+var builtinValidator = newRuleEngineConfigValidator()
+
+func Test_constantOperandValidator(t *testing.T) {
+	type args struct {
+		fs Fields
+		ct *ConditionType
+	}
+	tests := []struct {
+		name          string
+		args          args
+		validatorFunc conditionTypeValidatorFunc
+		wantErr       *RuleEngineError
+	}{
+		{
+			name: "valid",
+			args: args{
+				ct: &ConditionType{
+					Operator: MatchOperator,
+					Operands: []*Operand{
+						{
+							Type: Field,
+						},
+						{
+							Type: Constant,
+						},
+					},
+				},
+			},
+			validatorFunc: constantOperandValidator(secondOperand),
+			wantErr:       nil,
+		},
+		{
+			name: "invalid",
+			args: args{
+				ct: &ConditionType{
+					Operator: MatchOperator,
+					Operands: []*Operand{
+						{
+							Type: Field,
+						},
+						{
+							Type: Field,
+						},
+					},
+				},
+			},
+			validatorFunc: constantOperandValidator(secondOperand),
+			wantErr:       newError(ErrCodeInvalidOperandType),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotErr := tt.validatorFunc(tt.args.ct, tt.args.fs); !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("constantOperandValidator() = %v, want %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_patternOperandValidator(t *testing.T) {
+	type args struct {
+		fs Fields
+		ct *ConditionType
+	}
+	tests := []struct {
+		name          string
+		args          args
+		validatorFunc conditionTypeValidatorFunc
+		wantErr       *RuleEngineError
+	}{
+		{
+			name: "valid",
+			args: args{
+				ct: &ConditionType{
+					Operator: MatchOperator,
+					Operands: []*Operand{
+						{
+							Type:      Field,
+							ValueType: String,
+							Val:       "promoCode",
+						},
+						{
+							Type:      Constant,
+							ValueType: String,
+							Val:       "^PROMO[0-9]+$",
+						},
+					},
+				},
+			},
+			validatorFunc: patternOperandValidator(secondOperand),
+			wantErr:       nil,
+		},
+		{
+			name: "invalid_Pattern",
+			args: args{
+				ct: &ConditionType{
+					Operator: MatchOperator,
+					Operands: []*Operand{
+						{
+							Type:      Field,
+							ValueType: String,
+							Val:       "promoCode",
+						},
+						{
+							Type:      Constant,
+							ValueType: String,
+							Val:       "^PROMO[0-9+$",
+						},
+					},
+				},
+			},
+			validatorFunc: patternOperandValidator(secondOperand),
+			wantErr:       newError(ErrCodeParsingFailed),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotErr := tt.validatorFunc(tt.args.ct, tt.args.fs)
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("patternOperandValidator() = %v, want %v", gotErr, tt.wantErr)
+			}
+			if gotErr == nil {
+				if _, ok := tt.args.ct.Operands[secondOperand].typedValue.(*regexp.Regexp); !ok {
+					t.Errorf("patternOperandValidator() typedValue = %v, want *regexp.Regexp", tt.args.ct.Operands[secondOperand].typedValue)
+				}
+			}
+		})
+	}
+}
+
+func Test_setOperandValidator(t *testing.T) {
+	type args struct {
+		fs Fields
+		ct *ConditionType
+	}
+	tests := []struct {
+		name          string
+		args          args
+		validatorFunc conditionTypeValidatorFunc
+		want          set[any]
+		wantErr       *RuleEngineError
+	}{
+		{
+			name: "valid",
+			args: args{
+				ct: &ConditionType{
+					Operator: InOperator,
+					Operands: []*Operand{
+						{
+							Type:      Field,
+							ValueType: Float,
+							Val:       "rating",
+						},
+						{
+							Type:      Constant,
+							ValueType: Float,
+							Val:       "4.5, 5",
+						},
+					},
+				},
+			},
+			validatorFunc: setOperandValidator(secondOperand),
+			want:          testValueSet(float64(4.5), float64(5)),
+			wantErr:       nil,
+		},
+		{
+			name: "invalid_ValueParsingFailed",
+			args: args{
+				ct: &ConditionType{
+					Operator: InOperator,
+					Operands: []*Operand{
+						{
+							Type:      Field,
+							ValueType: Float,
+							Val:       "rating",
+						},
+						{
+							Type:      Constant,
+							ValueType: Float,
+							Val:       "4.5,five",
+						},
+					},
+				},
+			},
+			validatorFunc: setOperandValidator(secondOperand),
+			wantErr:       newError(ErrCodeParsingFailed),
+		},
+		{
+			name: "invalid_NotANumber",
+			args: args{
+				ct: &ConditionType{
+					Operator: InOperator,
+					Operands: []*Operand{
+						{
+							Type:      Field,
+							ValueType: Float,
+							Val:       "rating",
+						},
+						{
+							Type:      Constant,
+							ValueType: Float,
+							Val:       "4.5,NaN",
+						},
+					},
+				},
+			},
+			validatorFunc: setOperandValidator(secondOperand),
+			wantErr:       newError(ErrCodeInvalidOperand),
+		},
+		{
+			name: "invalid_ValueType",
+			args: args{
+				ct: &ConditionType{
+					Operator: InOperator,
+					Operands: []*Operand{
+						{
+							Type:      Field,
+							ValueType: unknownValueType,
+							Val:       "rating",
+						},
+						{
+							Type:      Constant,
+							ValueType: unknownValueType,
+							Val:       "4.5,5",
+						},
+					},
+				},
+			},
+			validatorFunc: setOperandValidator(secondOperand),
+			wantErr:       newError(ErrCodeInvalidValueType),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotErr := tt.validatorFunc(tt.args.ct, tt.args.fs)
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("setOperandValidator() = %v, want %v", gotErr, tt.wantErr)
+			}
+			if gotErr == nil && !reflect.DeepEqual(tt.args.ct.Operands[secondOperand].typedValue, tt.want) {
+				t.Errorf("setOperandValidator() typedValue = %v, want %v", tt.args.ct.Operands[secondOperand].typedValue, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateFieldOptions(t *testing.T) {
+	type args struct {
+		fs           Fields
+		fieldOptions map[string]*FieldOption
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    any
+		wantErr *RuleEngineError
+	}{
+		{
+			name: "valid_Default",
+			args: args{
+				fs:           Fields{"PaxCount": Integer},
+				fieldOptions: map[string]*FieldOption{"PaxCount": {Default: testStringPtr("1")}},
+			},
+			want:    int64(1),
+			wantErr: nil,
+		},
+		{
+			name: "valid_Nullable",
+			args: args{
+				fs:           Fields{"PaxCount": Integer},
+				fieldOptions: map[string]*FieldOption{"PaxCount": {Nullable: true}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid_UnknownField",
+			args: args{
+				fs:           Fields{"PaxCount": Integer},
+				fieldOptions: map[string]*FieldOption{"unknown": {Nullable: true}},
+			},
+			wantErr: newError(ErrCodeFieldNotFound),
+		},
+		{
+			name: "invalid_DefaultAndNullable",
+			args: args{
+				fs:           Fields{"PaxCount": Integer},
+				fieldOptions: map[string]*FieldOption{"PaxCount": {Default: testStringPtr("1"), Nullable: true}},
+			},
+			wantErr: newError(ErrCodeInvalidFieldOption),
+		},
+		{
+			name: "invalid_EmptyOption",
+			args: args{
+				fs:           Fields{"PaxCount": Integer},
+				fieldOptions: map[string]*FieldOption{"PaxCount": {}},
+			},
+			wantErr: newError(ErrCodeInvalidFieldOption),
+		},
+		{
+			name: "invalid_DefaultParsingFailed",
+			args: args{
+				fs:           Fields{"PaxCount": Integer},
+				fieldOptions: map[string]*FieldOption{"PaxCount": {Default: testStringPtr("one")}},
+			},
+			wantErr: newError(ErrCodeParsingFailed),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotErr := validateFieldOptions(tt.args.fs, tt.args.fieldOptions, time.RFC3339)
+			if !isErrorEqual(gotErr, tt.wantErr) {
+				t.Errorf("validateFieldOptions() = %v, want %v", gotErr, tt.wantErr)
+			}
+			if gotErr == nil && tt.args.fieldOptions["PaxCount"].typedDefault != tt.want {
+				t.Errorf("validateFieldOptions() typedDefault = %v, want %v", tt.args.fieldOptions["PaxCount"].typedDefault, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	config := &RuleEngineConfig{
+		Fields: Fields{
+			"amount":    Integer,
+			"$amount":   Integer,
+			"city":      unknownValueType,
+			"user.tier": unknownValueType,
+		},
+		FieldOptions: map[string]*FieldOption{
+			"unknown": {Nullable: true},
+		},
+		ConditionTypes: map[string]*ConditionType{
+			"amountMoreThan100": {Operator: GreaterOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Integer, Val: "amount"},
+				{Type: Constant, ValueType: Integer, Val: "100"},
+			}},
+			"amountMoreThanX": {Operator: GreaterOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Integer, Val: "amount"},
+				{Type: Constant, ValueType: Integer, Val: "X"},
+			}},
+			"unknownOperator": {Operator: "unknown"},
+		},
+		Rules: map[string]*RuleConfig{
+			"ruleA": {Priority: 1, RootCondition: &Condition{Type: OrCondition, SubConditions: []*Condition{
+				{Type: "amountMoreThan100"},
+				{Type: AndCondition, SubConditions: []*Condition{{Type: "amountMoreThan100"}}},
+				{Type: "unknown"},
+			}}},
+			"ruleB":  {Priority: 2},
+			"ruleC":  {Priority: 3, RootCondition: &Condition{Type: "amountMoreThan100"}},
+			"rule.D": {Priority: 4},
+		},
+	}
+	want := []struct {
+		path    string
+		errCode uint
+	}{
+		{path: `fields["$amount"]`, errCode: ErrCodeInvalidFieldName},
+		{path: `fields["city"]`, errCode: ErrCodeInvalidValueType},
+		{path: `fields["user.tier"]`, errCode: ErrCodeInvalidValueType},
+		{path: `fieldOptions["unknown"]`, errCode: ErrCodeFieldNotFound},
+		{path: `conditionTypes["amountMoreThanX"]`, errCode: ErrCodeParsingFailed},
+		{path: `conditionTypes["unknownOperator"]`, errCode: ErrCodeInvalidOperator},
+		{path: `rules["rule.D"].condition`, errCode: ErrCodeInvalidConditionType},
+		{path: `rules["ruleA"].condition.subConditions[1]`, errCode: ErrCodeInvalidSubConditionCount},
+		{path: `rules["ruleA"].condition.subConditions[2]`, errCode: ErrCodeConditionTypeNotFound},
+		{path: `rules["ruleB"].condition`, errCode: ErrCodeInvalidConditionType},
+	}
+
+	// order of problems is deterministic
+	for i := 0; i < 10; i++ {
+		got := Validate(config)
+		if len(got) != len(want) {
+			t.Fatalf("Validate() got %v problems, want %v, got %v", len(got), len(want), got)
+		}
+		for j, problem := range got {
+			if problem.Path != want[j].path || problem.Err.ErrCode != want[j].errCode {
+				t.Errorf("Validate() problem at %v got %v, want path %v and ErrCode %v", j, problem, want[j].path,
+					want[j].errCode)
+			}
+		}
+	}
+
+	if got := Validate(testUpdateConfig()); len(got) != 0 {
+		t.Errorf("Validate() got %v, want no problem", got)
+	}
+}
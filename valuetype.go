@@ -23,28 +23,74 @@ const (
 
 	//	'Float' is 64 bit signed float
 	Float
+
+	//	'DateTime' is a point in time, represented as RFC3339 string (ex. "2023-03-14T10:30:00+05:30")
+	DateTime
+
+	//	'Duration' is elapsed time, represented as duration string (ex. "1h30m", "-90s") having days as optional leading
+	//	unit (ex. "30d", "1.5d", "1d12h")
+	Duration
+
+	//	'BooleanList' is list of Boolean, represented as comma separated values (ex. "true,false")
+	BooleanList
+
+	//	'StringList' is list of String, represented as comma separated values (ex. "electronics,books"),
+	//	leading and trailing spaces of every value are ignored
+	StringList
+
+	//	'IntegerList' is list of Integer, represented as comma separated values (ex. "1,2,3")
+	IntegerList
+
+	//	'FloatList' is list of Float, represented as comma separated values (ex. "1.5,2.5")
+	FloatList
 )
 
 var (
 	valueType_Name = map[ValueType]string{
-		1: "Boolean",
-		2: "String",
-		3: "Integer",
-		4: "Float",
+		1:  "Boolean",
+		2:  "String",
+		3:  "Integer",
+		4:  "Float",
+		5:  "DateTime",
+		6:  "Duration",
+		7:  "List<Boolean>",
+		8:  "List<String>",
+		9:  "List<Integer>",
+		10: "List<Float>",
 	}
 	valueType_Value = map[string]ValueType{
-		"bool":    1,
-		"Bool":    1,
-		"boolean": 1,
-		"Boolean": 1,
-		"string":  2,
-		"String":  2,
-		"int":     3,
-		"Int":     3,
-		"integer": 3,
-		"Integer": 3,
-		"float":   4,
-		"Float":   4,
+		"bool":          1,
+		"Bool":          1,
+		"boolean":       1,
+		"Boolean":       1,
+		"string":        2,
+		"String":        2,
+		"int":           3,
+		"Int":           3,
+		"integer":       3,
+		"Integer":       3,
+		"float":         4,
+		"Float":         4,
+		"datetime":      5,
+		"DateTime":      5,
+		"duration":      6,
+		"Duration":      6,
+		"list<bool>":    7,
+		"list<boolean>": 7,
+		"List<Boolean>": 7,
+		"list<string>":  8,
+		"List<String>":  8,
+		"list<int>":     9,
+		"list<integer>": 9,
+		"List<Integer>": 9,
+		"list<float>":   10,
+		"List<Float>":   10,
+	}
+	listValueType_ElementType = map[ValueType]ValueType{
+		BooleanList: Boolean,
+		StringList:  String,
+		IntegerList: Integer,
+		FloatList:   Float,
 	}
 )
 
@@ -110,6 +156,16 @@ func (valueType ValueType) isString() bool {
 	return valueType == String
 }
 
+func (valueType ValueType) isList() bool {
+	_, ok := listValueType_ElementType[valueType]
+	return ok
+}
+
+// 'elementType' gives valueType of list elements for list valueType, unknownValueType otherwise
+func (valueType ValueType) elementType() ValueType {
+	return listValueType_ElementType[valueType]
+}
+
 // comma separated value type list
 var valueTypeList string
 
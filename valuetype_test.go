@@ -31,6 +31,16 @@ func TestValueType_isValid(t *testing.T) {
 			valueType: String,
 			want:      true,
 		},
+		{
+			name:      "Valid_ValueType_DateTime",
+			valueType: DateTime,
+			want:      true,
+		},
+		{
+			name:      "Valid_ValueType_Duration",
+			valueType: Duration,
+			want:      true,
+		},
 		{
 			name:      "Invalid_ValueType",
 			valueType: unknownValueType,
@@ -72,6 +82,16 @@ func TestValueType_String(t *testing.T) {
 			valueType: String,
 			want:      "String",
 		},
+		{
+			name:      "ValueType_DateTime",
+			valueType: DateTime,
+			want:      "DateTime",
+		},
+		{
+			name:      "ValueType_Duration",
+			valueType: Duration,
+			want:      "Duration",
+		},
 		{
 			name:      "ValueType_Unknown",
 			valueType: unknownValueType,
@@ -193,6 +213,70 @@ func Test_parseValueType(t *testing.T) {
 			want:    Float,
 			wantErr: false,
 		},
+		{
+			name: "valid_datetime",
+			args: args{
+				s: "datetime",
+			},
+			want:    DateTime,
+			wantErr: false,
+		},
+		{
+			name: "valid_DateTime",
+			args: args{
+				s: "DateTime",
+			},
+			want:    DateTime,
+			wantErr: false,
+		},
+		{
+			name: "valid_duration",
+			args: args{
+				s: "duration",
+			},
+			want:    Duration,
+			wantErr: false,
+		},
+		{
+			name: "valid_Duration",
+			args: args{
+				s: "Duration",
+			},
+			want:    Duration,
+			wantErr: false,
+		},
+		{
+			name: "valid_listString",
+			args: args{
+				s: "list<string>",
+			},
+			want:    StringList,
+			wantErr: false,
+		},
+		{
+			name: "valid_ListInteger",
+			args: args{
+				s: "List<Integer>",
+			},
+			want:    IntegerList,
+			wantErr: false,
+		},
+		{
+			name: "valid_listFloat",
+			args: args{
+				s: "list<float>",
+			},
+			want:    FloatList,
+			wantErr: false,
+		},
+		{
+			name: "valid_listBool",
+			args: args{
+				s: "list<bool>",
+			},
+			want:    BooleanList,
+			wantErr: false,
+		},
 		{
 			name: "invalid_valueType",
 			args: args{
@@ -450,3 +534,41 @@ func TestValueType_isString(t *testing.T) {
 		})
 	}
 }
+
+func TestValueType_elementType(t *testing.T) {
+	tests := []struct {
+		name      string
+		valueType ValueType
+		want      ValueType
+		wantList  bool
+	}{
+		{
+			name:      "valid_StringList",
+			valueType: StringList,
+			want:      String,
+			wantList:  true,
+		},
+		{
+			name:      "valid_IntegerList",
+			valueType: IntegerList,
+			want:      Integer,
+			wantList:  true,
+		},
+		{
+			name:      "invalid_String",
+			valueType: String,
+			want:      unknownValueType,
+			wantList:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.valueType.elementType(); got != tt.want {
+				t.Errorf("ValueType.elementType() = %v, want %v", got, tt.want)
+			}
+			if got := tt.valueType.isList(); got != tt.wantList {
+				t.Errorf("ValueType.isList() = %v, want %v", got, tt.wantList)
+			}
+		})
+	}
+}
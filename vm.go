@@ -0,0 +1,513 @@
+package ruleenginecore
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// 'opcode' defines instruction type of a compiled rule program
+type opcode uint8
+
+const (
+	// evaluates fallback evaluator against parsed input
+	opEvaluate opcode = iota
+	opIntegerFieldConstant
+	opIntegerFieldField
+	opFloatFieldConstant
+	opFloatFieldField
+	opStringFieldConstant
+	opStringFieldField
+	opBooleanFieldConstant
+	opBooleanFieldField
+	opIsPresent
+	opIsMissing
+
+	// sets constant result, used for logical condition without sub conditions
+	opConstant
+
+	// jumps to target if result is false, used for short-circuit of 'and' condition
+	opJumpIfFalse
+
+	// jumps to target if result is true, used for short-circuit of 'or' condition
+	opJumpIfTrue
+	opNot
+)
+
+// 'comparison' defines comparison of typed comparison instructions
+type comparison uint8
+
+const (
+	cmpGreater comparison = iota
+	cmpGreaterEqual
+	cmpLess
+	cmpLessEqual
+	cmpEqual
+	cmpNotEqual
+)
+
+// returns comparison having operands swapped, ex. 'a > b' is same as 'b < a'
+func (cmp comparison) swap() comparison {
+	switch cmp {
+	case cmpGreater:
+		return cmpLess
+	case cmpGreaterEqual:
+		return cmpLessEqual
+	case cmpLess:
+		return cmpGreater
+	case cmpLessEqual:
+		return cmpGreaterEqual
+	}
+	return cmp
+}
+
+func compare[T int64 | float64 | string](cmp comparison, a, b T) bool {
+	switch cmp {
+	case cmpGreater:
+		return a > b
+	case cmpGreaterEqual:
+		return a >= b
+	case cmpLess:
+		return a < b
+	case cmpLessEqual:
+		return a <= b
+	case cmpEqual:
+		return a == b
+	case cmpNotEqual:
+		return a != b
+	}
+	// no-op
+	panic("Invalid comparison")
+}
+
+func compareBoolean(cmp comparison, a, b bool) bool {
+	if cmp == cmpEqual {
+		return a == b
+	}
+	return a != b
+}
+
+// reports whether instruction evaluates a condition type
+func (op opcode) isCondition() bool {
+	return op <= opIsMissing
+}
+
+// memoized outcome of condition
+const (
+	conditionUnknown uint8 = iota
+	conditionFalse
+	conditionTrue
+)
+
+type instruction struct {
+	op  opcode
+	cmp comparison
+
+	// index of evaluated condition, outcome of condition is memoized per evaluation with this index
+	condition int
+
+	// field slots of operands
+	slot1 int
+	slot2 int
+
+	// jump target of jump instructions
+	target int
+
+	intConst    int64
+	floatConst  float64
+	stringConst string
+	boolConst   bool
+
+	// fallback evaluator for 'opEvaluate' instruction
+	eval evaluator
+}
+
+// 'program' is a rule compiled into flat instructions, instructions maintain a single boolean result, which is
+// the outcome of rule once program is completed
+type program []instruction
+
+// 'slotInput' maintains parsed input as field values indexed by field slots, missing field has nil value
+type slotInput struct {
+	slots  []any
+	fields []slotField
+
+	// evaluation time for 'Now' operands
+	now time.Time
+
+	// input as map of field name and value, built on demand for conditions which are not compiled into instructions
+	values parsedInput
+
+	// checks context cancellation while running programs if not nil
+	check *cancellationCheck
+
+	// memoized outcome of conditions, indexed by condition index
+	memo []uint8
+
+	// memoized outcome hits and misses
+	hits   uint64
+	misses uint64
+}
+
+// field having a slot
+type slotField struct {
+	name      string
+	valueType ValueType
+}
+
+// assigns slot to every field in field name order
+func newFieldSlots(fs Fields) map[string]int {
+	fieldSlots := make(map[string]int, len(fs))
+	for slot, field := range newSlotFields(fs) {
+		fieldSlots[field.name] = slot
+	}
+	return fieldSlots
+}
+
+// returns fields indexed by slot, same as 'newFieldSlots'
+func newSlotFields(fs Fields) []slotField {
+	names := make([]string, 0, len(fs))
+	for name := range fs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ret := make([]slotField, len(names))
+	for slot, name := range names {
+		ret[slot] = slotField{name: name, valueType: fs[name]}
+	}
+	return ret
+}
+
+// returns input having every field missing, parsing assigns field values to the slots
+func (re *ruleEngine) newSlotInput() *slotInput {
+	return &slotInput{
+		slots:  make([]any, len(re.slotFields)),
+		fields: re.slotFields,
+		memo:   make([]uint8, re.conditionCount),
+	}
+}
+
+// returns input as map of field name and value along with evaluation time, map is built once
+func (in *slotInput) parsedValues() parsedInput {
+	if in.values == nil {
+		in.values = make(parsedInput, len(in.slots)+1)
+		for slot, val := range in.slots {
+			if val != nil {
+				in.values[in.fields[slot].name] = val
+			}
+		}
+		if !in.now.IsZero() {
+			in.values[nowFieldName] = in.now
+		}
+	}
+	return in.values
+}
+
+// returns copy of input having its own cancellation check and memo, which is required for concurrent evaluation
+func (in *slotInput) fork(ctx context.Context) *slotInput {
+	ret := &slotInput{slots: in.slots, fields: in.fields, now: in.now, values: in.values,
+		memo: make([]uint8, len(in.memo))}
+	if in.check != nil {
+		ret.check = &cancellationCheck{ctx: ctx, interval: in.check.interval}
+	}
+	return ret
+}
+
+func (p program) run(in *slotInput) bool {
+	result := false
+	for pc := 0; pc < len(p); pc++ {
+		if in.check != nil && in.check.check() {
+			return false
+		}
+
+		ins := &p[pc]
+		if ins.op.isCondition() {
+			if memo := in.memo[ins.condition]; memo != conditionUnknown {
+				in.hits++
+				result = memo == conditionTrue
+				continue
+			}
+
+			in.misses++
+			result = ins.evaluateCondition(in)
+			if result {
+				in.memo[ins.condition] = conditionTrue
+			} else {
+				in.memo[ins.condition] = conditionFalse
+			}
+			continue
+		}
+
+		switch ins.op {
+		case opConstant:
+			result = ins.boolConst
+		case opJumpIfFalse:
+			if !result {
+				pc = ins.target - 1
+			}
+		case opJumpIfTrue:
+			if result {
+				pc = ins.target - 1
+			}
+		case opNot:
+			result = !result
+		}
+	}
+	return result
+}
+
+func (ins *instruction) evaluateCondition(in *slotInput) bool {
+	result := false
+	switch ins.op {
+	case opEvaluate:
+		result = ins.eval.evaluate(in.parsedValues())
+	case opIntegerFieldConstant:
+		val, ok := in.slots[ins.slot1].(int64)
+		result = ok && compare(ins.cmp, val, ins.intConst)
+	case opIntegerFieldField:
+		val1, ok1 := in.slots[ins.slot1].(int64)
+		val2, ok2 := in.slots[ins.slot2].(int64)
+		result = ok1 && ok2 && compare(ins.cmp, val1, val2)
+	case opFloatFieldConstant:
+		val, ok := in.slots[ins.slot1].(float64)
+		result = ok && compare(ins.cmp, val, ins.floatConst)
+	case opFloatFieldField:
+		val1, ok1 := in.slots[ins.slot1].(float64)
+		val2, ok2 := in.slots[ins.slot2].(float64)
+		result = ok1 && ok2 && compare(ins.cmp, val1, val2)
+	case opStringFieldConstant:
+		val, ok := in.slots[ins.slot1].(string)
+		result = ok && compare(ins.cmp, val, ins.stringConst)
+	case opStringFieldField:
+		val1, ok1 := in.slots[ins.slot1].(string)
+		val2, ok2 := in.slots[ins.slot2].(string)
+		result = ok1 && ok2 && compare(ins.cmp, val1, val2)
+	case opBooleanFieldConstant:
+		val, ok := in.slots[ins.slot1].(bool)
+		result = ok && compareBoolean(ins.cmp, val, ins.boolConst)
+	case opBooleanFieldField:
+		val1, ok1 := in.slots[ins.slot1].(bool)
+		val2, ok2 := in.slots[ins.slot2].(bool)
+		result = ok1 && ok2 && compareBoolean(ins.cmp, val1, val2)
+	case opIsPresent:
+		result = in.slots[ins.slot1] != nil
+	case opIsMissing:
+		result = in.slots[ins.slot1] == nil
+	}
+	return result
+}
+
+// 'programCompiler' compiles rules of an engine, conditions are indexed by their evaluators, hence condition type
+// shared by rules has the same index
+type programCompiler struct {
+	fieldSlots map[string]int
+	conditions map[evaluator]int
+	program    program
+}
+
+// 'conditions' sets index of known conditions, new conditions get next index
+func newProgramCompiler(fieldSlots map[string]int, conditions map[evaluator]int) *programCompiler {
+	return &programCompiler{fieldSlots: fieldSlots, conditions: conditions}
+}
+
+// count of indexed conditions
+func (c *programCompiler) conditionCount() int {
+	return len(c.conditions)
+}
+
+// compiles rule evaluator tree into program, comparisons of Integer, Float, String and Boolean operands are compiled
+// into typed instructions, other conditions fall back to their evaluators
+func (c *programCompiler) compileProgram(rootEvaluator evaluator) program {
+	c.program = program{}
+	c.compile(rootEvaluator)
+	return c.program
+}
+
+func (c *programCompiler) compile(eval evaluator) {
+	le, ok := eval.(*logicalEvaluator)
+	if !ok {
+		ins := c.leaf(eval)
+		index, ok := c.conditions[eval]
+		if !ok {
+			index = len(c.conditions)
+			c.conditions[eval] = index
+		}
+		ins.condition = index
+		c.program = append(c.program, ins)
+		return
+	}
+
+	if le.operator == NegationCondition {
+		c.compile(le.innerEvaluators[0])
+		c.program = append(c.program, instruction{op: opNot})
+		return
+	}
+
+	if len(le.innerEvaluators) == 0 {
+		c.program = append(c.program, instruction{op: opConstant, boolConst: le.operator == AndCondition})
+		return
+	}
+
+	jumpOp := opJumpIfFalse
+	if le.operator == OrCondition {
+		jumpOp = opJumpIfTrue
+	}
+
+	jumps := []int{}
+	for i, inner := range le.innerEvaluators {
+		c.compile(inner)
+		if i < len(le.innerEvaluators)-1 {
+			jumps = append(jumps, len(c.program))
+			c.program = append(c.program, instruction{op: jumpOp})
+		}
+	}
+	for _, jump := range jumps {
+		c.program[jump].target = len(c.program)
+	}
+}
+
+func (c *programCompiler) leaf(eval evaluator) instruction {
+	fallback := instruction{op: opEvaluate, eval: eval}
+
+	// typed instructions evaluates to false for missing fields, same as nullable evaluator
+	inner := eval
+	if ne, ok := eval.(*nullableEvaluator); ok {
+		inner = ne.innerEvaluator
+	}
+
+	if cmp, operands, ok := comparisonOf(inner); ok {
+		if ins, ok := c.comparison(cmp, operands); ok {
+			return ins
+		}
+		return fallback
+	}
+
+	switch e := inner.(type) {
+	case *isPresentEvaluator:
+		if slot, ok := c.fieldSlot(e.operands[0]); ok {
+			return instruction{op: opIsPresent, slot1: slot}
+		}
+		return fallback
+	case *isMissingEvaluator:
+		if slot, ok := c.fieldSlot(e.operands[0]); ok {
+			return instruction{op: opIsMissing, slot1: slot}
+		}
+		return fallback
+	}
+	return fallback
+}
+
+// returns comparison and operands of comparison evaluator
+func comparisonOf(eval evaluator) (comparison, []*Operand, bool) {
+	switch e := eval.(type) {
+	case *greaterEvaluator:
+		return cmpGreater, e.operands, true
+	case *greaterEqualEvaluator:
+		return cmpGreaterEqual, e.operands, true
+	case *lessEvaluator:
+		return cmpLess, e.operands, true
+	case *lessEqualEvaluator:
+		return cmpLessEqual, e.operands, true
+	case *equalEvaluator:
+		return cmpEqual, e.operands, true
+	case *notEqualEvaluator:
+		return cmpNotEqual, e.operands, true
+	}
+	return 0, nil, false
+}
+
+// compiles comparison having at least one field operand
+func (c *programCompiler) comparison(cmp comparison, operands []*Operand) (instruction, bool) {
+	first, second := operands[0], operands[1]
+	if !first.isField() {
+		first, second, cmp = second, first, cmp.swap()
+	}
+
+	slot1, ok := c.fieldSlot(first)
+	if !ok {
+		return instruction{}, false
+	}
+	ins := instruction{cmp: cmp, slot1: slot1}
+
+	if slot2, ok := c.fieldSlot(second); ok {
+		ins.slot2 = slot2
+		switch first.ValueType {
+		case Integer:
+			ins.op = opIntegerFieldField
+		case Float:
+			ins.op = opFloatFieldField
+		case String:
+			ins.op = opStringFieldField
+		case Boolean:
+			ins.op = opBooleanFieldField
+		default:
+			return instruction{}, false
+		}
+		return ins, true
+	}
+
+	if second.Type != Constant {
+		return instruction{}, false
+	}
+
+	switch first.ValueType {
+	case Integer:
+		ins.op, ins.intConst = opIntegerFieldConstant, second.typedValue.(int64)
+	case Float:
+		ins.op, ins.floatConst = opFloatFieldConstant, second.typedValue.(float64)
+	case String:
+		ins.op, ins.stringConst = opStringFieldConstant, second.typedValue.(string)
+	case Boolean:
+		ins.op, ins.boolConst = opBooleanFieldConstant, second.typedValue.(bool)
+	default:
+		return instruction{}, false
+	}
+	return ins, true
+}
+
+func (c *programCompiler) fieldSlot(op *Operand) (int, bool) {
+	if !op.isField() {
+		return 0, false
+	}
+	slot, ok := c.fieldSlots[op.Val]
+	return slot, ok
+}
+
+// 'ConditionCacheStats' reports condition outcome cache usage across evaluations of a RuleEngine
+type ConditionCacheStats struct {
+	// count of conditions, whose outcome is reused within an evaluation
+	Hits uint64 `json:"hits"`
+
+	// count of conditions evaluated
+	Misses uint64 `json:"misses"`
+}
+
+// ratio of hits to total conditions considered, 0 if no condition is considered
+func (s ConditionCacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+type conditionCacheStats struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// records hits and misses of the evaluated input
+func (s *conditionCacheStats) record(in *slotInput) {
+	if s == nil {
+		return
+	}
+	s.hits.Add(in.hits)
+	s.misses.Add(in.misses)
+}
+
+func (s *conditionCacheStats) stats() ConditionCacheStats {
+	if s == nil {
+		return ConditionCacheStats{}
+	}
+	return ConditionCacheStats{Hits: s.hits.Load(), Misses: s.misses.Load()}
+}
@@ -0,0 +1,258 @@
+package ruleenginecore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_newFieldSlots(t *testing.T) {
+	got := newFieldSlots(Fields{"b": Integer, "a": String, "c": Boolean})
+	want := map[string]int{"a": 0, "b": 1, "c": 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newFieldSlots() = %v, want %v", got, want)
+	}
+}
+
+func Test_comparison_swap(t *testing.T) {
+	tests := []struct {
+		cmp  comparison
+		want comparison
+	}{
+		{cmp: cmpGreater, want: cmpLess},
+		{cmp: cmpGreaterEqual, want: cmpLessEqual},
+		{cmp: cmpLess, want: cmpGreater},
+		{cmp: cmpLessEqual, want: cmpGreaterEqual},
+		{cmp: cmpEqual, want: cmpEqual},
+		{cmp: cmpNotEqual, want: cmpNotEqual},
+	}
+	for _, tt := range tests {
+		if got := tt.cmp.swap(); got != tt.want {
+			t.Errorf("comparison.swap() of %v = %v, want %v", tt.cmp, got, tt.want)
+		}
+	}
+}
+
+func Test_compileProgram(t *testing.T) {
+	fieldSlots := map[string]int{"amount": 0, "pax": 1}
+	amountMoreThan10 := &greaterEvaluator{operands: []*Operand{
+		{Type: Field, ValueType: Integer, Val: "amount"},
+		{Type: Constant, ValueType: Integer, Val: "10", typedValue: int64(10)},
+	}}
+	paxLessThanAmount := &lessEvaluator{operands: []*Operand{
+		{Type: Field, ValueType: Integer, Val: "pax"},
+		{Type: Field, ValueType: Integer, Val: "amount"},
+	}}
+	fiveLessThanPax := &lessEvaluator{operands: []*Operand{
+		{Type: Constant, ValueType: Integer, Val: "5", typedValue: int64(5)},
+		{Type: Field, ValueType: Integer, Val: "pax"},
+	}}
+	amountIn := &inEvaluator{operands: []*Operand{
+		{Type: Field, ValueType: Integer, Val: "amount"},
+		{Type: Constant, ValueType: Integer, Val: "1,2", typedValue: testValueSet(int64(1), int64(2))},
+	}}
+
+	tests := []struct {
+		name string
+		eval evaluator
+		want program
+	}{
+		{
+			name: "fieldConstant",
+			eval: amountMoreThan10,
+			want: program{{op: opIntegerFieldConstant, cmp: cmpGreater, slot1: 0, intConst: 10}},
+		},
+		{
+			name: "constantField",
+			eval: fiveLessThanPax,
+			want: program{{op: opIntegerFieldConstant, cmp: cmpGreater, slot1: 1, intConst: 5}},
+		},
+		{
+			name: "fieldField",
+			eval: paxLessThanAmount,
+			want: program{{op: opIntegerFieldField, cmp: cmpLess, slot1: 1, slot2: 0}},
+		},
+		{
+			name: "fallback",
+			eval: amountIn,
+			want: program{{op: opEvaluate, eval: amountIn}},
+		},
+		{
+			name: "logical",
+			eval: &logicalEvaluator{operator: AndCondition, innerEvaluators: []evaluator{
+				amountMoreThan10,
+				&logicalEvaluator{operator: OrCondition, innerEvaluators: []evaluator{
+					paxLessThanAmount,
+					&logicalEvaluator{operator: NegationCondition, innerEvaluators: []evaluator{amountIn}},
+				}},
+			}},
+			want: program{
+				{op: opIntegerFieldConstant, cmp: cmpGreater, slot1: 0, intConst: 10},
+				{op: opJumpIfFalse, target: 6},
+				{op: opIntegerFieldField, cmp: cmpLess, condition: 1, slot1: 1, slot2: 0},
+				{op: opJumpIfTrue, target: 6},
+				{op: opEvaluate, condition: 2, eval: amountIn},
+				{op: opNot},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := newProgramCompiler(fieldSlots, map[evaluator]int{})
+			if got := compiler.compileProgram(tt.eval); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("compileProgram() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_program_run(t *testing.T) {
+	fields := Fields{"amount": Integer, "price": Float, "city": String, "hotel": Boolean, "coupon": String}
+	fieldSlots := newFieldSlots(fields)
+	buildCtx := &ruleBuildContext{
+		conditionTypes: map[string]*ConditionType{
+			"amountMoreThan10": {Operator: GreaterOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Integer, Val: "amount"},
+				{Type: Constant, ValueType: Integer, Val: "10", typedValue: int64(10)},
+			}},
+			"priceAtMost99.5": {Operator: LessEqualOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Float, Val: "price"},
+				{Type: Constant, ValueType: Float, Val: "99.5", typedValue: 99.5},
+			}},
+			"cityIsGoa": {Operator: EqualOperator, Operands: []*Operand{
+				{Type: Constant, ValueType: String, Val: "Goa", typedValue: "Goa"},
+				{Type: Field, ValueType: String, Val: "city"},
+			}},
+			"notHotel": {Operator: NotEqualOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Boolean, Val: "hotel"},
+				{Type: Constant, ValueType: Boolean, Val: "true", typedValue: true},
+			}},
+			"couponNotCity": {Operator: NotEqualOperator, Operands: []*Operand{
+				{Type: Field, ValueType: String, Val: "coupon"},
+				{Type: Field, ValueType: String, Val: "city"},
+			}},
+			"couponPresent": {Operator: IsPresentOperator, Operands: []*Operand{
+				{Type: Field, ValueType: String, Val: "coupon"},
+			}},
+			"cityContainsGo": {Operator: ContainOperator, Operands: []*Operand{
+				{Type: Field, ValueType: String, Val: "city"},
+				{Type: Constant, ValueType: String, Val: "Go", typedValue: "Go"},
+			}},
+		},
+		fields:       fields,
+		fieldOptions: map[string]*FieldOption{"coupon": {Nullable: true}},
+		evalFactory:  newEvaluatorFactory(),
+		fieldSlots:   fieldSlots,
+	}
+
+	conditions := []*Condition{
+		{Type: OrCondition, SubConditions: []*Condition{
+			{Type: AndCondition, SubConditions: []*Condition{{Type: "amountMoreThan10"}, {Type: "priceAtMost99.5"}}},
+			{Type: NegationCondition, SubConditions: []*Condition{{Type: "cityIsGoa"}}},
+		}},
+		{Type: AndCondition, SubConditions: []*Condition{
+			{Type: "notHotel"},
+			{Type: "couponNotCity"},
+			{Type: OrCondition, SubConditions: []*Condition{{Type: "couponPresent"}, {Type: "cityContainsGo"}}},
+		}},
+		{Type: NegationCondition, SubConditions: []*Condition{{Type: "couponNotCity"}}},
+	}
+	inputs := []parsedInput{
+		{"amount": int64(20), "price": 50.0, "city": "Goa", "hotel": false, "coupon": "X"},
+		{"amount": int64(5), "price": 50.0, "city": "Goa", "hotel": false},
+		{"amount": int64(20), "price": 150.0, "city": "Delhi", "hotel": true, "coupon": "Delhi"},
+		{"amount": int64(20), "price": 99.5, "city": "Gokarna", "hotel": false, "coupon": "Goa"},
+	}
+
+	for i, condition := range conditions {
+		eval, err := ruleEvaluatorBuild(condition, buildCtx)
+		if err != nil {
+			t.Fatalf("ruleEvaluatorBuild() gotErr %v", err)
+		}
+		compiler := newProgramCompiler(fieldSlots, map[evaluator]int{})
+		p := compiler.compileProgram(eval)
+		re := &ruleEngine{fieldSlots: fieldSlots, conditionCount: compiler.conditionCount()}
+		for j, input := range inputs {
+			if got, want := p.run(testSlotInput(re, input)), eval.evaluate(input); got != want {
+				t.Errorf("program.run() condition %v input %v = %v, want %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestRuleEngine_ConditionCacheStats(t *testing.T) {
+	engine, err := New(&RuleEngineConfig{
+		Fields: Fields{"amount": Integer, "pax": Integer},
+		ConditionTypes: map[string]*ConditionType{
+			"amountMoreThan10": {Operator: GreaterOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Integer, Val: "amount"},
+				{Type: Constant, ValueType: Integer, Val: "10"},
+			}},
+			"paxMoreThan2": {Operator: GreaterOperator, Operands: []*Operand{
+				{Type: Field, ValueType: Integer, Val: "pax"},
+				{Type: Constant, ValueType: Integer, Val: "2"},
+			}},
+		},
+		Rules: map[string]*RuleConfig{
+			"rule1": {Priority: 1, RootCondition: &Condition{Type: "amountMoreThan10"}},
+			"rule2": {Priority: 2, RootCondition: &Condition{Type: AndCondition, SubConditions: []*Condition{
+				{Type: "amountMoreThan10"}, {Type: "paxMoreThan2"},
+			}}},
+			"rule3": {Priority: 3, RootCondition: &Condition{Type: OrCondition, SubConditions: []*Condition{
+				{Type: "amountMoreThan10"}, {Type: "paxMoreThan2"},
+			}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() gotErr %v", err)
+	}
+
+	got, gotErr := engine.Evaluate(context.TODO(), Input{"amount": "20", "pax": "1"}, EvaluateOptions().Complete())
+	if gotErr != nil {
+		t.Fatalf("Evaluate() gotErr %v", gotErr)
+	}
+	if len(got) != 2 || got[0].Rulename != "rule1" || got[1].Rulename != "rule3" {
+		t.Errorf("Evaluate() got %v, want [rule1 rule3]", got)
+	}
+
+	// every condition type is evaluated once, remaining references are hits
+	want := ConditionCacheStats{Hits: 2, Misses: 2}
+	if stats := engine.ConditionCacheStats(); stats != want {
+		t.Errorf("ConditionCacheStats() = %v, want %v", stats, want)
+	}
+	if rate := want.HitRate(); rate != 0.5 {
+		t.Errorf("ConditionCacheStats.HitRate() = %v, want 0.5", rate)
+	}
+
+	if _, gotErr := engine.EvaluateSingleRule(context.TODO(), Input{"amount": "20", "pax": "1"}, "rule2"); gotErr != nil {
+		t.Fatalf("EvaluateSingleRule() gotErr %v", gotErr)
+	}
+	want = ConditionCacheStats{Hits: 2, Misses: 4}
+	if stats := engine.ConditionCacheStats(); stats != want {
+		t.Errorf("ConditionCacheStats() = %v, want %v", stats, want)
+	}
+}
+
+// returns slot input having values of the parsed input, evaluators not compiled into instructions consider the parsed
+// input as is
+func testSlotInput(re *ruleEngine, values parsedInput) *slotInput {
+	ret := &slotInput{
+		slots:  make([]any, len(re.fieldSlots)),
+		fields: make([]slotField, len(re.fieldSlots)),
+		values: values,
+		memo:   make([]uint8, re.conditionCount),
+	}
+	for name, slot := range re.fieldSlots {
+		ret.fields[slot] = slotField{name: name}
+		ret.slots[slot] = values[name]
+	}
+	return ret
+}
+
+// returns parsed input of slot input, nil for nil input
+func testParsedValues(in *slotInput) parsedInput {
+	if in == nil {
+		return nil
+	}
+	return in.parsedValues()
+}